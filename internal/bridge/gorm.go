@@ -0,0 +1,102 @@
+package bridge
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	ogorm "github.com/kozmod/oniontx/gorm"
+	ostdlib "github.com/kozmod/oniontx/stdlib"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUnsupportedConnPool = fmt.Errorf("unsupported gorm connection pool")
+)
+
+// GormStdlibTransactor manages [gorm] transactions and exposes
+// the underlying [sql.Tx] to [database/sql] based repositories.
+type GormStdlibTransactor struct {
+	*ogorm.Transactor
+
+	db *sql.DB
+}
+
+// NewGormStdlibTransactor returns new GormStdlibTransactor.
+//
+// NewGormStdlibTransactor returns [ErrUnsupportedConnPool] when the [gorm] transactions
+// are not begun as [sql.Tx], since [sql.DB] would execute the statements outside the transaction.
+func NewGormStdlibTransactor(transactor *ogorm.Transactor) (*GormStdlibTransactor, error) {
+	beginner := transactor.TxBeginner()
+	if err := checkConnPool(beginner.Statement.ConnPool); err != nil {
+		return nil, fmt.Errorf("gorm-stdlib bridge - check connection pool: %w", err)
+	}
+	db, err := beginner.DB()
+	if err != nil {
+		return nil, fmt.Errorf("gorm-stdlib bridge - get sql.DB: %w", err)
+	}
+	return &GormStdlibTransactor{
+		Transactor: transactor,
+		db:         db,
+	}, nil
+}
+
+// GetExecutor returns [sql.Tx] of the [gorm] transaction obtained from [context.Context] or [sql.DB].
+func (t *GormStdlibTransactor) GetExecutor(ctx context.Context) ostdlib.Executor {
+	tx, ok := t.Transactor.TryGetTx(ctx)
+	if !ok {
+		return t.db
+	}
+	return unwrapSqlTx(tx.Statement.ConnPool)
+}
+
+// checkConnPool checks that [gorm.DB.Begin] begins [sql.Tx] on the connection pool,
+// [gorm.PreparedStmtDB] ([gorm.Config.PrepareStmt]) begins the transaction on the wrapped pool.
+func checkConnPool(pool gorm.ConnPool) error {
+	switch pool := pool.(type) {
+	case gorm.TxBeginner:
+		return nil
+	case *gorm.PreparedStmtDB:
+		return checkConnPool(pool.ConnPool)
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedConnPool, pool)
+	}
+}
+
+// unwrapSqlTx returns [sql.Tx] of the [gorm] transaction's connection pool checked by checkConnPool,
+// [gorm.PreparedStmtTX] ([gorm.Config.PrepareStmt]) wraps the transaction.
+func unwrapSqlTx(pool gorm.ConnPool) *sql.Tx {
+	if stmtTx, ok := pool.(*gorm.PreparedStmtTX); ok {
+		pool = stmtTx.Tx
+	}
+	return pool.(*sql.Tx)
+}
+
+// StdlibGormTransactor manages [sql.Tx] transactions and runs [gorm] on top of them.
+type StdlibGormTransactor struct {
+	*ostdlib.Transactor
+
+	db *gorm.DB
+}
+
+// NewStdlibGormTransactor returns new StdlibGormTransactor.
+//
+// `db` should be opened on the same [sql.DB] which is used by `transactor`.
+func NewStdlibGormTransactor(transactor *ostdlib.Transactor, db *gorm.DB) *StdlibGormTransactor {
+	return &StdlibGormTransactor{
+		Transactor: transactor,
+		db:         db,
+	}
+}
+
+// GetExecutor returns [gorm.DB] bound to [sql.Tx] obtained from [context.Context] or [gorm.DB] as is.
+func (t *StdlibGormTransactor) GetExecutor(ctx context.Context) *gorm.DB {
+	tx, ok := t.Transactor.TryGetTx(ctx)
+	if !ok {
+		return t.db
+	}
+	// the same way as gorm.DB.Begin sets the transaction to the new session
+	db := t.db.Session(&gorm.Session{NewDB: true, Context: ctx})
+	db.Statement.ConnPool = tx
+	return db
+}
@@ -0,0 +1,254 @@
+package bridge
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	ogorm "github.com/kozmod/oniontx/gorm"
+	ostdlib "github.com/kozmod/oniontx/stdlib"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+	"github.com/kozmod/oniontx-examples/internal/fault"
+	gormexample "github.com/kozmod/oniontx-examples/internal/gorm"
+	stdlibexample "github.com/kozmod/oniontx-examples/internal/stdlib"
+	"github.com/kozmod/oniontx-examples/internal/testdb"
+)
+
+const (
+	textRecord = "text_A"
+)
+
+func Test_GormStdlibTransactor(t *testing.T) {
+	t.Parallel()
+
+	var (
+		schema  = testdb.NewSchema(t, entity.ConnectionString)
+		db      = ConnectDB(t, schema.ConnectionString())
+		gormDBs = map[string]*gorm.DB{
+			"default": ConnectGorm(t, db),
+			"prepare_stmt": ConnectGorm(t, db, func(config *gorm.Config) {
+				config.PrepareStmt = true
+			}),
+		}
+	)
+
+	t.Cleanup(func() {
+		err := db.Close()
+		assert.NoError(t, err)
+	})
+
+	createTextRecords := func(ctx context.Context, gormDB *gorm.DB, gormErr, stdlibErr bool) error {
+		transactor, err := NewGormStdlibTransactor(ogorm.NewTransactor(gormDB))
+		assert.NoError(t, err)
+
+		var (
+			gormRepository   = gormexample.NewTextRepository(fault.NewGormRepoTransactor(transactor.Transactor, ExpectedErrorInjector(gormErr)))
+			stdlibRepository = stdlibexample.NewTextRepository(fault.NewStdlibRepoTransactor(transactor, ExpectedErrorInjector(stdlibErr)))
+		)
+		return transactor.WithinTx(ctx, func(ctx context.Context) error {
+			if err := gormRepository.RawInsert(ctx, textRecord); err != nil {
+				return err
+			}
+			return stdlibRepository.Insert(ctx, textRecord)
+		})
+	}
+
+	for name, gormDB := range gormDBs {
+		t.Run(name, func(t *testing.T) {
+			t.Run("success_create", func(t *testing.T) {
+				ctx := context.Background()
+
+				err := createTextRecords(ctx, gormDB, false, false)
+				assert.NoError(t, err)
+
+				{
+					records, err := GetTextRecords(db)
+					assert.NoError(t, err)
+					assert.Len(t, records, 2)
+					for _, record := range records {
+						assert.Equal(t, textRecord, record)
+					}
+				}
+
+				t.Cleanup(func() {
+					err = ClearDB(db)
+					assert.NoError(t, err)
+				})
+			})
+			t.Run("gorm_error_and_rollback", func(t *testing.T) {
+				ctx := context.Background()
+
+				err := createTextRecords(ctx, gormDB, true, false)
+				assert.Error(t, err)
+				assert.ErrorIs(t, err, entity.ErrExpected)
+
+				{
+					records, err := GetTextRecords(db)
+					assert.NoError(t, err)
+					assert.Len(t, records, 0)
+				}
+
+				t.Cleanup(func() {
+					err = ClearDB(db)
+					assert.NoError(t, err)
+				})
+			})
+			t.Run("stdlib_error_and_rollback", func(t *testing.T) {
+				ctx := context.Background()
+
+				err := createTextRecords(ctx, gormDB, false, true)
+				assert.Error(t, err)
+				assert.ErrorIs(t, err, entity.ErrExpected)
+
+				{
+					records, err := GetTextRecords(db)
+					assert.NoError(t, err)
+					assert.Len(t, records, 0)
+				}
+
+				t.Cleanup(func() {
+					err = ClearDB(db)
+					assert.NoError(t, err)
+				})
+			})
+		})
+	}
+}
+
+func Test_StdlibGormTransactor(t *testing.T) {
+	t.Parallel()
+
+	var (
+		schema = testdb.NewSchema(t, entity.ConnectionString)
+		db     = ConnectDB(t, schema.ConnectionString())
+		gormDB = ConnectGorm(t, db)
+	)
+
+	t.Cleanup(func() {
+		err := db.Close()
+		assert.NoError(t, err)
+	})
+
+	createTextRecords := func(ctx context.Context, gormErr, stdlibErr bool) error {
+		var (
+			transactor       = NewStdlibGormTransactor(ostdlib.NewTransactor(db), gormDB)
+			gormRepository   = gormexample.NewTextRepository(fault.NewGormRepoTransactor(transactor, ExpectedErrorInjector(gormErr)))
+			stdlibRepository = stdlibexample.NewTextRepository(fault.NewStdlibRepoTransactor(transactor.Transactor, ExpectedErrorInjector(stdlibErr)))
+		)
+		return transactor.WithinTx(ctx, func(ctx context.Context) error {
+			if err := stdlibRepository.Insert(ctx, textRecord); err != nil {
+				return err
+			}
+			return gormRepository.Insert(ctx, gormexample.Text{Val: textRecord})
+		})
+	}
+
+	t.Run("success_create", func(t *testing.T) {
+		ctx := context.Background()
+
+		err := createTextRecords(ctx, false, false)
+		assert.NoError(t, err)
+
+		{
+			records, err := GetTextRecords(db)
+			assert.NoError(t, err)
+			assert.Len(t, records, 2)
+			for _, record := range records {
+				assert.Equal(t, textRecord, record)
+			}
+		}
+
+		t.Cleanup(func() {
+			err = ClearDB(db)
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("gorm_error_and_rollback", func(t *testing.T) {
+		ctx := context.Background()
+
+		err := createTextRecords(ctx, true, false)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+
+		{
+			records, err := GetTextRecords(db)
+			assert.NoError(t, err)
+			assert.Len(t, records, 0)
+		}
+
+		t.Cleanup(func() {
+			err = ClearDB(db)
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("stdlib_error_and_rollback", func(t *testing.T) {
+		ctx := context.Background()
+
+		err := createTextRecords(ctx, false, true)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+
+		{
+			records, err := GetTextRecords(db)
+			assert.NoError(t, err)
+			assert.Len(t, records, 0)
+		}
+
+		t.Cleanup(func() {
+			err = ClearDB(db)
+			assert.NoError(t, err)
+		})
+	})
+}
+
+func Test_GormStdlibTransactor_GetExecutor(t *testing.T) {
+	t.Run("prepare_stmt", func(t *testing.T) {
+		var (
+			ctx         = context.Background()
+			db, sqlMock = NewSqlMock(t)
+			gormDB      = ConnectGorm(t, db, func(config *gorm.Config) {
+				config.PrepareStmt = true
+			})
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectCommit()
+
+		transactor, err := NewGormStdlibTransactor(ogorm.NewTransactor(gormDB))
+		assert.NoError(t, err)
+
+		err = transactor.WithinTx(ctx, func(ctx context.Context) error {
+			assert.IsType(t, &sql.Tx{}, transactor.GetExecutor(ctx))
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("unsupported_conn_pool", func(t *testing.T) {
+		var (
+			db, _       = NewSqlMock(t)
+			gormDB, err = gorm.Open(postgres.New(postgres.Config{Conn: connPoolBeginner{DB: db}}), &gorm.Config{})
+		)
+		assert.NoError(t, err)
+
+		transactor, err := NewGormStdlibTransactor(ogorm.NewTransactor(gormDB))
+		assert.Nil(t, transactor)
+		assert.ErrorIs(t, err, ErrUnsupportedConnPool)
+	})
+}
+
+// connPoolBeginner begins transactions as [gorm.ConnPool] instead of [sql.Tx].
+type connPoolBeginner struct {
+	*sql.DB
+}
+
+func (p connPoolBeginner) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	return p.DB.BeginTx(ctx, opts)
+}
+
+func (p connPoolBeginner) GetDBConn() (*sql.DB, error) {
+	return p.DB, nil
+}
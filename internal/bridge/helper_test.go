@@ -0,0 +1,98 @@
+package bridge
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+	"github.com/kozmod/oniontx-examples/internal/fault"
+)
+
+func ConnectDB(t *testing.T, connString string) *sql.DB {
+	connConfig, err := pgx.ParseConfig(connString)
+	assert.NoError(t, err)
+
+	connStr := stdlib.RegisterConnConfig(connConfig)
+	db, err := sql.Open("pgx", connStr)
+	assert.NoError(t, err)
+
+	err = db.Ping()
+	assert.NoError(t, err)
+
+	return db
+}
+
+// NewSqlMock returns [sql.DB] backed by [sqlmock.Sqlmock], which matches the expected SQL exactly and in order.
+func NewSqlMock(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+		sqlMock.ExpectClose()
+		assert.NoError(t, db.Close())
+	})
+	return db, sqlMock
+}
+
+func ConnectGorm(t *testing.T, db *sql.DB, opts ...func(config *gorm.Config)) *gorm.DB {
+	var config gorm.Config
+	for _, opt := range opts {
+		opt(&config)
+	}
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &config)
+	assert.NoError(t, err)
+	return gormDB
+}
+
+func ConnectSqlx(t *testing.T, connString string) *sqlx.DB {
+	db, err := sqlx.Connect("postgres", connString)
+	assert.NoError(t, err)
+
+	err = db.Ping()
+	assert.NoError(t, err)
+	return db
+}
+
+func ClearDB(db *sql.DB) error {
+	_, err := db.Exec("TRUNCATE TABLE text;")
+	if err != nil {
+		return fmt.Errorf("clear DB: %w", err)
+	}
+	return nil
+}
+
+func GetTextRecords(db *sql.DB) ([]string, error) {
+	row, err := db.Query("SELECT val FROM text;")
+	if err != nil {
+		return nil, fmt.Errorf("get `text` records: %w", err)
+	}
+
+	var texts []string
+	for row.Next() {
+		var text string
+		err = row.Scan(&text)
+		if err != nil {
+			return nil, fmt.Errorf("scan `text` records: %w", err)
+		}
+		texts = append(texts, text)
+	}
+	return texts, nil
+}
+
+// ExpectedErrorInjector returns [fault.Injector] which fails any statement with [entity.ErrExpected] when the error is expected.
+func ExpectedErrorInjector(errorExpected bool) *fault.Injector {
+	if !errorExpected {
+		return fault.NewInjector()
+	}
+	return fault.NewInjector(fault.Rule{Point: fault.BeforeExec, Err: entity.ErrExpected})
+}
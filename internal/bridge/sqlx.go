@@ -0,0 +1,42 @@
+package bridge
+
+import (
+	"context"
+
+	osqlx "github.com/kozmod/oniontx/sqlx"
+	ostdlib "github.com/kozmod/oniontx/stdlib"
+)
+
+// SqlxStdlibTransactor manages [sqlx] transactions and exposes them as [ostdlib.Executor].
+type SqlxStdlibTransactor struct {
+	*osqlx.Transactor
+}
+
+// NewSqlxStdlibTransactor returns new SqlxStdlibTransactor.
+func NewSqlxStdlibTransactor(transactor *osqlx.Transactor) *SqlxStdlibTransactor {
+	return &SqlxStdlibTransactor{
+		Transactor: transactor,
+	}
+}
+
+// GetExecutor returns [sqlx.Tx] obtained from [context.Context] or [sqlx.DB] as [ostdlib.Executor].
+func (t *SqlxStdlibTransactor) GetExecutor(ctx context.Context) ostdlib.Executor {
+	return t.Transactor.GetExecutor(ctx)
+}
+
+// StdlibSqlxTransactor manages [sql.Tx] transactions and exposes them as [osqlx.Executor].
+type StdlibSqlxTransactor struct {
+	*ostdlib.Transactor
+}
+
+// NewStdlibSqlxTransactor returns new StdlibSqlxTransactor.
+func NewStdlibSqlxTransactor(transactor *ostdlib.Transactor) *StdlibSqlxTransactor {
+	return &StdlibSqlxTransactor{
+		Transactor: transactor,
+	}
+}
+
+// GetExecutor returns [sql.Tx] obtained from [context.Context] or [sql.DB] as [osqlx.Executor].
+func (t *StdlibSqlxTransactor) GetExecutor(ctx context.Context) osqlx.Executor {
+	return t.Transactor.GetExecutor(ctx)
+}
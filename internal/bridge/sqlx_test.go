@@ -0,0 +1,179 @@
+package bridge
+
+import (
+	"context"
+	"testing"
+
+	osqlx "github.com/kozmod/oniontx/sqlx"
+	ostdlib "github.com/kozmod/oniontx/stdlib"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+	"github.com/kozmod/oniontx-examples/internal/fault"
+	sqlxexample "github.com/kozmod/oniontx-examples/internal/sqlx"
+	stdlibexample "github.com/kozmod/oniontx-examples/internal/stdlib"
+	"github.com/kozmod/oniontx-examples/internal/testdb"
+	"github.com/kozmod/oniontx-examples/internal/usecase"
+)
+
+func Test_SqlxStdlibTransactor(t *testing.T) {
+	t.Parallel()
+
+	var (
+		schema = testdb.NewSchema(t, entity.ConnectionString)
+		db     = ConnectSqlx(t, schema.ConnectionString())
+	)
+
+	t.Cleanup(func() {
+		err := db.Close()
+		assert.NoError(t, err)
+	})
+
+	createTextRecords := func(ctx context.Context, sqlxErr, stdlibErr bool) error {
+		var (
+			transactor       = NewSqlxStdlibTransactor(osqlx.NewTransactor(db))
+			sqlxRepository   = sqlxexample.NewTextRepository(fault.NewSqlxRepoTransactor(transactor.Transactor, ExpectedErrorInjector(sqlxErr)))
+			stdlibRepository = stdlibexample.NewTextRepository(fault.NewStdlibRepoTransactor(transactor, ExpectedErrorInjector(stdlibErr)))
+			useCase          = usecase.NewUseCase(sqlxRepository, stdlibRepository, transactor)
+		)
+		return useCase.CreateTextRecords(ctx, textRecord)
+	}
+
+	t.Run("success_create", func(t *testing.T) {
+		ctx := context.Background()
+
+		err := createTextRecords(ctx, false, false)
+		assert.NoError(t, err)
+
+		{
+			records, err := GetTextRecords(db.DB)
+			assert.NoError(t, err)
+			assert.Len(t, records, 2)
+			for _, record := range records {
+				assert.Equal(t, textRecord, record)
+			}
+		}
+
+		t.Cleanup(func() {
+			err = ClearDB(db.DB)
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("sqlx_error_and_rollback", func(t *testing.T) {
+		ctx := context.Background()
+
+		err := createTextRecords(ctx, true, false)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+
+		{
+			records, err := GetTextRecords(db.DB)
+			assert.NoError(t, err)
+			assert.Len(t, records, 0)
+		}
+
+		t.Cleanup(func() {
+			err = ClearDB(db.DB)
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("stdlib_error_and_rollback", func(t *testing.T) {
+		ctx := context.Background()
+
+		err := createTextRecords(ctx, false, true)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+
+		{
+			records, err := GetTextRecords(db.DB)
+			assert.NoError(t, err)
+			assert.Len(t, records, 0)
+		}
+
+		t.Cleanup(func() {
+			err = ClearDB(db.DB)
+			assert.NoError(t, err)
+		})
+	})
+}
+
+func Test_StdlibSqlxTransactor(t *testing.T) {
+	t.Parallel()
+
+	var (
+		schema = testdb.NewSchema(t, entity.ConnectionString)
+		db     = ConnectSqlx(t, schema.ConnectionString())
+	)
+
+	t.Cleanup(func() {
+		err := db.Close()
+		assert.NoError(t, err)
+	})
+
+	createTextRecords := func(ctx context.Context, sqlxErr, stdlibErr bool) error {
+		var (
+			transactor       = NewStdlibSqlxTransactor(ostdlib.NewTransactor(db.DB))
+			stdlibRepository = stdlibexample.NewTextRepository(fault.NewStdlibRepoTransactor(transactor.Transactor, ExpectedErrorInjector(stdlibErr)))
+			sqlxRepository   = sqlxexample.NewTextRepository(fault.NewSqlxRepoTransactor(transactor, ExpectedErrorInjector(sqlxErr)))
+			useCase          = usecase.NewUseCase(stdlibRepository, sqlxRepository, transactor)
+		)
+		return useCase.CreateTextRecords(ctx, textRecord)
+	}
+
+	t.Run("success_create", func(t *testing.T) {
+		ctx := context.Background()
+
+		err := createTextRecords(ctx, false, false)
+		assert.NoError(t, err)
+
+		{
+			records, err := GetTextRecords(db.DB)
+			assert.NoError(t, err)
+			assert.Len(t, records, 2)
+			for _, record := range records {
+				assert.Equal(t, textRecord, record)
+			}
+		}
+
+		t.Cleanup(func() {
+			err = ClearDB(db.DB)
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("sqlx_error_and_rollback", func(t *testing.T) {
+		ctx := context.Background()
+
+		err := createTextRecords(ctx, true, false)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+
+		{
+			records, err := GetTextRecords(db.DB)
+			assert.NoError(t, err)
+			assert.Len(t, records, 0)
+		}
+
+		t.Cleanup(func() {
+			err = ClearDB(db.DB)
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("stdlib_error_and_rollback", func(t *testing.T) {
+		ctx := context.Background()
+
+		err := createTextRecords(ctx, false, true)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+
+		{
+			records, err := GetTextRecords(db.DB)
+			assert.NoError(t, err)
+			assert.Len(t, records, 0)
+		}
+
+		t.Cleanup(func() {
+			err = ClearDB(db.DB)
+			assert.NoError(t, err)
+		})
+	})
+}
@@ -9,5 +9,22 @@ const (
 )
 
 var (
-	ErrExpected = fmt.Errorf("expected fake error")
+	ErrExpected  = fmt.Errorf("expected fake error")
+	ErrPanicInTx = fmt.Errorf("panic in transaction")
 )
+
+// PanicError contains a value recovered from a panic within a transaction and the panicking goroutine's stack.
+//
+// PanicError wraps [ErrPanicInTx].
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%v [%v]", ErrPanicInTx, e.Value)
+}
+
+func (e *PanicError) Unwrap() error {
+	return ErrPanicInTx
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrDuplicate      = fmt.Errorf("duplicate")
+	ErrForeignKey     = fmt.Errorf("foreign key violation")
+	ErrNotNull        = fmt.Errorf("not null violation")
+	ErrCheckViolation = fmt.Errorf("check violation")
+	ErrSerialization  = fmt.Errorf("serialization failure")
+)
+
+// sqlStates maps Postgres SQLSTATE codes to the domain errors.
+var sqlStates = map[string]error{
+	"23505": ErrDuplicate,
+	"23503": ErrForeignKey,
+	"23502": ErrNotNull,
+	"23514": ErrCheckViolation,
+	"40001": ErrSerialization,
+}
+
+// sqlStateError is implemented by Postgres errors of the different drivers ([pgconn.PgError], [pq.Error]).
+type sqlStateError interface {
+	SQLState() string
+}
+
+// MapError wraps the database error with the domain error matching the error's SQLSTATE code,
+// so [errors.Is] works the same way whatever the driver.
+//
+// Errors without a known SQLSTATE code are returned as is.
+func MapError(err error) error {
+	var stateErr sqlStateError
+	if !errors.As(err, &stateErr) {
+		return err
+	}
+	domainErr, ok := sqlStates[stateErr.SQLState()]
+	if !ok {
+		return err
+	}
+	return fmt.Errorf("%w: %w", domainErr, err)
+}
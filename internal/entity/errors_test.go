@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MapError(t *testing.T) {
+	var (
+		domainErrs = []error{
+			ErrDuplicate,
+			ErrForeignKey,
+			ErrNotNull,
+			ErrCheckViolation,
+			ErrSerialization,
+		}
+		assertOnly = func(t *testing.T, err, exp error) {
+			for _, domainErr := range domainErrs {
+				if errors.Is(exp, domainErr) {
+					assert.ErrorIs(t, err, domainErr)
+					continue
+				}
+				assert.NotErrorIs(t, err, domainErr)
+			}
+		}
+	)
+
+	for code, exp := range sqlStates {
+		t.Run(code, func(t *testing.T) {
+			t.Run("pgx", func(t *testing.T) {
+				pgErr := &pgconn.PgError{Code: code}
+				err := MapError(fmt.Errorf("repository: %w", pgErr))
+				assertOnly(t, err, exp)
+				assert.ErrorIs(t, err, pgErr)
+			})
+			t.Run("pq", func(t *testing.T) {
+				pqErr := &pq.Error{Code: pq.ErrorCode(code)}
+				err := MapError(fmt.Errorf("repository: %w", pqErr))
+				assertOnly(t, err, exp)
+				assert.ErrorIs(t, err, pqErr)
+			})
+		})
+	}
+	t.Run("unknown_code", func(t *testing.T) {
+		pgErr := &pgconn.PgError{Code: "42P01"}
+		err := MapError(pgErr)
+		assert.Equal(t, pgErr, err)
+	})
+	t.Run("not_sql_state_error", func(t *testing.T) {
+		err := MapError(ErrExpected)
+		assert.Equal(t, ErrExpected, err)
+	})
+	t.Run("nil", func(t *testing.T) {
+		assert.NoError(t, MapError(nil))
+	})
+}
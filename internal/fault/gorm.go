@@ -0,0 +1,147 @@
+package fault
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+)
+
+const (
+	gormPluginName  = "oniontx:fault"
+	gormConnPoolKey = "oniontx:fault:conn_pool"
+)
+
+type (
+	gormRepoTransactor interface {
+		GetExecutor(ctx context.Context) *gorm.DB
+	}
+)
+
+// GormRepoTransactor wraps [gorm.ConnPool] of the decorated transactor's executor with [ConnPool].
+type GormRepoTransactor struct {
+	transactor gormRepoTransactor
+	injector   *Injector
+}
+
+// NewGormRepoTransactor returns new GormRepoTransactor.
+func NewGormRepoTransactor(transactor gormRepoTransactor, injector *Injector) *GormRepoTransactor {
+	return &GormRepoTransactor{
+		transactor: transactor,
+		injector:   injector,
+	}
+}
+
+// GetExecutor returns a new session of the decorated transactor's executor which injects faults.
+func (t *GormRepoTransactor) GetExecutor(ctx context.Context) *gorm.DB {
+	db := t.transactor.GetExecutor(ctx).Session(&gorm.Session{Context: ctx})
+	db.Statement.ConnPool = &ConnPool{
+		pool:     db.Statement.ConnPool,
+		injector: t.injector,
+	}
+	return db
+}
+
+// ConnPool implements [gorm.ConnPool] and injects faults at the [BeforeExec] and the [AfterExec] points of the statements.
+//
+// QueryRowContext does not reach the points, since [sql.Row] can't carry the injected error.
+type ConnPool struct {
+	pool     gorm.ConnPool
+	injector *Injector
+}
+
+func (p *ConnPool) dropConn(ctx context.Context) func() error {
+	return func() error {
+		_, err := p.pool.ExecContext(ctx, dropConnQuery)
+		return err
+	}
+}
+
+func (p *ConnPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return execute(ctx, p.injector, query, p.dropConn(ctx), func() (*sql.Stmt, error) {
+		return p.pool.PrepareContext(ctx, query)
+	})
+}
+
+func (p *ConnPool) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return execute(ctx, p.injector, query, p.dropConn(ctx), func() (sql.Result, error) {
+		return p.pool.ExecContext(ctx, query, args...)
+	})
+}
+
+func (p *ConnPool) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return execute(ctx, p.injector, query, p.dropConn(ctx), func() (*sql.Rows, error) {
+		return p.pool.QueryContext(ctx, query, args...)
+	})
+}
+
+func (p *ConnPool) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return p.pool.QueryRowContext(ctx, query, args...)
+}
+
+// GormPlugin implements [gorm.Plugin] and injects faults into the statements of the [gorm.DB] instance.
+//
+// The plugin's callbacks replace the statement's [gorm.ConnPool] with [ConnPool] for the statement's execution,
+// so the Rules match the statement's SQL built by gorm.
+type GormPlugin struct {
+	injector *Injector
+}
+
+// NewGormPlugin returns new GormPlugin.
+func NewGormPlugin(injector *Injector) *GormPlugin {
+	return &GormPlugin{
+		injector: injector,
+	}
+}
+
+// Name returns the plugin name.
+func (p *GormPlugin) Name() string {
+	return gormPluginName
+}
+
+// Initialize registers the plugin callbacks.
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	var (
+		callback = db.Callback()
+		before   = gormPluginName + ":before"
+		after    = gormPluginName + ":after"
+	)
+	for _, err := range []error{
+		callback.Create().Before("gorm:create").Register(before, p.before),
+		callback.Create().After("gorm:create").Register(after, p.after),
+		callback.Query().Before("gorm:query").Register(before, p.before),
+		callback.Query().After("gorm:query").Register(after, p.after),
+		callback.Update().Before("gorm:update").Register(before, p.before),
+		callback.Update().After("gorm:update").Register(after, p.after),
+		callback.Delete().Before("gorm:delete").Register(before, p.before),
+		callback.Delete().After("gorm:delete").Register(after, p.after),
+		callback.Row().Before("gorm:row").Register(before, p.before),
+		callback.Row().After("gorm:row").Register(after, p.after),
+		callback.Raw().Before("gorm:raw").Register(before, p.before),
+		callback.Raw().After("gorm:raw").Register(after, p.after),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *GormPlugin) before(db *gorm.DB) {
+	db.InstanceSet(gormConnPoolKey, db.Statement.ConnPool)
+	db.Statement.ConnPool = &ConnPool{
+		pool:     db.Statement.ConnPool,
+		injector: p.injector,
+	}
+}
+
+// after restores the statement's [gorm.ConnPool], since gorm commits the default transaction with it.
+func (p *GormPlugin) after(db *gorm.DB) {
+	v, ok := db.InstanceGet(gormConnPoolKey)
+	if !ok {
+		return
+	}
+	if pool, ok := v.(gorm.ConnPool); ok {
+		db.Statement.ConnPool = pool
+	}
+}
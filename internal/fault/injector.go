@@ -0,0 +1,166 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// Point is a place where [Injector] injects faults.
+type Point string
+
+const (
+	// BeforeExec is reached before a statement executes.
+	BeforeExec Point = "before_exec"
+	// AfterExec is reached after a statement executes successfully, the statement's result is discarded by the fault.
+	AfterExec Point = "after_exec"
+	// Commit is reached before the commit of the transaction.
+	Commit Point = "commit"
+	// Rollback is reached after the rollback of the transaction.
+	Rollback Point = "rollback"
+)
+
+// Rule describes a fault which is injected at the Point.
+type Rule struct {
+	Point Point
+	// Query is matched against the statement's SQL, nil means any statement.
+	// The [Commit] and the [Rollback] points have no statement, so they are not matched by the Rules with Query.
+	Query *regexp.Regexp
+	// Nth is the number of the Rule's matching call which the fault is injected on, zero means every matching call.
+	Nth int
+	// Err is the injected error, nil means the Rule adds Latency or drops the connection only.
+	Err error
+	// Latency is added to the call.
+	Latency time.Duration
+	// DropConn terminates the connection's backend, so the statement fails with the driver's own error.
+	// DropConn is applied at the [BeforeExec] and the [AfterExec] points only,
+	// [SQLExecutor] applies DropConn within a transaction only.
+	DropConn bool
+}
+
+// rule counts the Rule's matching calls.
+type rule struct {
+	Rule
+	calls int
+}
+
+func (r *rule) match(point Point, query string) bool {
+	if r.Point != point {
+		return false
+	}
+	if r.Query != nil && (query == "" || !r.Query.MatchString(query)) {
+		return false
+	}
+	r.calls++
+	return r.Nth == 0 || r.Nth == r.calls
+}
+
+// Injector counts calls of the Points and injects faults described by the Rules.
+type Injector struct {
+	mx    sync.Mutex
+	rules []*rule
+	calls map[Point]int
+}
+
+// NewInjector returns new Injector.
+func NewInjector(rules ...Rule) *Injector {
+	injector := Injector{
+		rules: make([]*rule, 0, len(rules)),
+		calls: make(map[Point]int),
+	}
+	for _, r := range rules {
+		injector.rules = append(injector.rules, &rule{Rule: r})
+	}
+	return &injector
+}
+
+// Inject registers the Point's call of the statement (empty for the [Commit] and the [Rollback] points)
+// and applies the matching Rules: waits for the sum of the Rules' latency and returns the first Rule's error.
+//
+// Inject returns the context's error when the context is done during the latency.
+func (i *Injector) Inject(ctx context.Context, point Point, query string) error {
+	_, err := i.inject(ctx, point, query)
+	return err
+}
+
+func (i *Injector) inject(ctx context.Context, point Point, query string) (dropConn bool, err error) {
+	var latency time.Duration
+
+	i.mx.Lock()
+	i.calls[point]++
+	n := i.calls[point]
+	for _, r := range i.rules {
+		if !r.match(point, query) {
+			continue
+		}
+		latency += r.Latency
+		dropConn = dropConn || r.DropConn
+		if err == nil && r.Err != nil {
+			err = fmt.Errorf("fault - %s #%d: %w", point, n, r.Err)
+		}
+	}
+	i.mx.Unlock()
+
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return dropConn, err
+}
+
+// Calls returns the number of the Point's calls.
+func (i *Injector) Calls(point Point) int {
+	i.mx.Lock()
+	defer i.mx.Unlock()
+	return i.calls[point]
+}
+
+// dropConnQuery terminates the backend of the connection which executes the statement.
+const dropConnQuery = `SELECT pg_terminate_backend(pg_backend_pid())`
+
+// execute calls the statement's function between the [BeforeExec] and the [AfterExec] points.
+// The result is closed when the fault is injected at the [AfterExec] point.
+//
+// dropConn executes dropConnQuery with the statement's connection.
+func execute[T any](ctx context.Context, injector *Injector, query string, dropConn func() error, fn func() (T, error)) (T, error) {
+	var nilResult T
+	if err := inject(ctx, injector, BeforeExec, query, dropConn); err != nil {
+		return nilResult, err
+	}
+	res, err := fn()
+	if err != nil {
+		return res, err
+	}
+	if err = inject(ctx, injector, AfterExec, query, dropConn); err != nil {
+		discard(res)
+		return nilResult, err
+	}
+	return res, nil
+}
+
+func inject(ctx context.Context, injector *Injector, point Point, query string, dropConn func() error) error {
+	drop, err := injector.inject(ctx, point, query)
+	if drop {
+		if dropErr := dropConn(); dropErr != nil {
+			return errors.Join(err, fmt.Errorf("fault - %s: drop connection: %w", point, dropErr))
+		}
+	}
+	return err
+}
+
+func discard(res any) {
+	switch closer := res.(type) {
+	case interface{ Close() error }:
+		_ = closer.Close()
+	case interface{ Close() }:
+		closer.Close()
+	}
+}
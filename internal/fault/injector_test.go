@@ -0,0 +1,127 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Injector(t *testing.T) {
+	t.Run("nth_call", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			expErr   = fmt.Errorf("some_error")
+			injector = NewInjector(Rule{Point: BeforeExec, Nth: 2, Err: expErr})
+		)
+
+		assert.NoError(t, injector.Inject(ctx, BeforeExec, ""))
+		assert.NoError(t, injector.Inject(ctx, AfterExec, ""))
+		assert.ErrorIs(t, injector.Inject(ctx, BeforeExec, ""), expErr)
+		assert.NoError(t, injector.Inject(ctx, BeforeExec, ""))
+		assert.Equal(t, 3, injector.Calls(BeforeExec))
+		assert.Equal(t, 1, injector.Calls(AfterExec))
+	})
+	t.Run("every_call", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			expErr   = fmt.Errorf("some_error")
+			injector = NewInjector(Rule{Point: Commit, Err: expErr})
+		)
+
+		assert.ErrorIs(t, injector.Inject(ctx, Commit, ""), expErr)
+		assert.ErrorIs(t, injector.Inject(ctx, Commit, ""), expErr)
+		assert.NoError(t, injector.Inject(ctx, Rollback, ""))
+	})
+	t.Run("query", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			expErr   = fmt.Errorf("some_error")
+			injector = NewInjector(Rule{
+				Point: BeforeExec,
+				Query: regexp.MustCompile(`^INSERT INTO text`),
+				Nth:   2,
+				Err:   expErr,
+			})
+		)
+
+		assert.NoError(t, injector.Inject(ctx, BeforeExec, `INSERT INTO text (val) VALUES ($1)`))
+		assert.NoError(t, injector.Inject(ctx, BeforeExec, `SELECT val FROM text`))
+		assert.ErrorIs(t, injector.Inject(ctx, BeforeExec, `INSERT INTO text (val) VALUES ($1)`), expErr)
+		assert.Equal(t, 3, injector.Calls(BeforeExec))
+	})
+	t.Run("query_and_commit", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			injector = NewInjector(Rule{Point: Commit, Query: regexp.MustCompile(`.*`), Err: fmt.Errorf("some_error")})
+		)
+
+		assert.NoError(t, injector.Inject(ctx, Commit, ""))
+	})
+	t.Run("latency", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			latency  = 50 * time.Millisecond
+			injector = NewInjector(Rule{Point: BeforeExec, Latency: latency})
+		)
+
+		start := time.Now()
+		assert.NoError(t, injector.Inject(ctx, BeforeExec, ""))
+		assert.GreaterOrEqual(t, time.Since(start), latency)
+	})
+	t.Run("latency_and_done_context", func(t *testing.T) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+			injector    = NewInjector(Rule{Point: BeforeExec, Latency: time.Minute})
+		)
+		defer cancel()
+
+		assert.ErrorIs(t, injector.Inject(ctx, BeforeExec, ""), context.DeadlineExceeded)
+	})
+	t.Run("execute_drop_conn", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			connErr  = fmt.Errorf("conn_error")
+			injector = NewInjector(Rule{Point: BeforeExec, DropConn: true})
+			dropped  = 0
+			executed = false
+		)
+
+		_, err := execute(ctx, injector, "", func() error {
+			dropped++
+			return connErr
+		}, func() (any, error) {
+			executed = true
+			return nil, nil
+		})
+		assert.ErrorIs(t, err, connErr)
+		assert.Equal(t, 1, dropped)
+		assert.False(t, executed)
+	})
+	t.Run("execute_after_exec", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			expErr   = fmt.Errorf("some_error")
+			injector = NewInjector(Rule{Point: AfterExec, Err: expErr})
+			closer   = closerFunc(0)
+		)
+
+		res, err := execute(ctx, injector, "", nil, func() (*closerFunc, error) {
+			return &closer, nil
+		})
+		assert.ErrorIs(t, err, expErr)
+		assert.Nil(t, res)
+		assert.Equal(t, closerFunc(1), closer)
+	})
+}
+
+// closerFunc counts Close calls.
+type closerFunc int
+
+func (c *closerFunc) Close() error {
+	*c++
+	return nil
+}
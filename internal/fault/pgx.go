@@ -0,0 +1,109 @@
+package fault
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	opgx "github.com/kozmod/oniontx/pgx"
+)
+
+type (
+	pgxRepoTransactor interface {
+		GetExecutor(ctx context.Context) opgx.Executor
+	}
+)
+
+// PgxRepoTransactor wraps [opgx.Executor] of the decorated transactor with [PgxExecutor].
+type PgxRepoTransactor struct {
+	transactor pgxRepoTransactor
+	injector   *Injector
+}
+
+// NewPgxRepoTransactor returns new PgxRepoTransactor.
+func NewPgxRepoTransactor(transactor pgxRepoTransactor, injector *Injector) *PgxRepoTransactor {
+	return &PgxRepoTransactor{
+		transactor: transactor,
+		injector:   injector,
+	}
+}
+
+// GetExecutor returns [opgx.Executor] which injects faults.
+func (t *PgxRepoTransactor) GetExecutor(ctx context.Context) opgx.Executor {
+	return &PgxExecutor{
+		executor: t.transactor.GetExecutor(ctx),
+		injector: t.injector,
+	}
+}
+
+// PgxExecutor implements [opgx.Executor] and injects faults at the [BeforeExec] and the [AfterExec] points.
+//
+// The row returned by QueryRow reaches the [AfterExec] point on Scan.
+type PgxExecutor struct {
+	executor opgx.Executor
+	injector *Injector
+}
+
+func (e *PgxExecutor) dropConn(ctx context.Context) func() error {
+	return func() error {
+		_, err := e.executor.Exec(ctx, dropConnQuery)
+		return err
+	}
+}
+
+func (e *PgxExecutor) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	return execute(ctx, e.injector, sql, e.dropConn(ctx), func() (pgconn.CommandTag, error) {
+		return e.executor.Exec(ctx, sql, arguments...)
+	})
+}
+
+func (e *PgxExecutor) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return execute(ctx, e.injector, sql, e.dropConn(ctx), func() (pgx.Rows, error) {
+		return e.executor.Query(ctx, sql, args...)
+	})
+}
+
+func (e *PgxExecutor) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	dropConn := e.dropConn(ctx)
+	if err := inject(ctx, e.injector, BeforeExec, sql, dropConn); err != nil {
+		return errRow{err: err}
+	}
+	return &row{
+		ctx:      ctx,
+		sql:      sql,
+		row:      e.executor.QueryRow(ctx, sql, args...),
+		injector: e.injector,
+		dropConn: dropConn,
+	}
+}
+
+func (e *PgxExecutor) Prepare(ctx context.Context, name, sql string) (*pgconn.StatementDescription, error) {
+	return execute(ctx, e.injector, sql, e.dropConn(ctx), func() (*pgconn.StatementDescription, error) {
+		return e.executor.Prepare(ctx, name, sql)
+	})
+}
+
+// row injects faults at the [AfterExec] point on Scan.
+type row struct {
+	ctx      context.Context
+	sql      string
+	row      pgx.Row
+	injector *Injector
+	dropConn func() error
+}
+
+func (r *row) Scan(dest ...any) error {
+	if err := r.row.Scan(dest...); err != nil {
+		return err
+	}
+	return inject(r.ctx, r.injector, AfterExec, r.sql, r.dropConn)
+}
+
+// errRow implements [pgx.Row] and returns the error on Scan.
+type errRow struct {
+	err error
+}
+
+func (r errRow) Scan(...any) error {
+	return r.err
+}
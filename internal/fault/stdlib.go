@@ -0,0 +1,142 @@
+package fault
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	osqlx "github.com/kozmod/oniontx/sqlx"
+	ostdlib "github.com/kozmod/oniontx/stdlib"
+)
+
+var (
+	// ErrDropConnOutsideTx is returned by [SQLExecutor] when the connection is dropped outside a transaction.
+	ErrDropConnOutsideTx = fmt.Errorf("drop connection outside transaction")
+)
+
+type (
+	stdlibRepoTransactor interface {
+		GetExecutor(ctx context.Context) ostdlib.Executor
+	}
+
+	sqlxRepoTransactor interface {
+		GetExecutor(ctx context.Context) osqlx.Executor
+	}
+)
+
+// StdlibRepoTransactor wraps [ostdlib.Executor] of the decorated transactor with [SQLExecutor].
+type StdlibRepoTransactor struct {
+	transactor stdlibRepoTransactor
+	injector   *Injector
+}
+
+// NewStdlibRepoTransactor returns new StdlibRepoTransactor.
+func NewStdlibRepoTransactor(transactor stdlibRepoTransactor, injector *Injector) *StdlibRepoTransactor {
+	return &StdlibRepoTransactor{
+		transactor: transactor,
+		injector:   injector,
+	}
+}
+
+// GetExecutor returns [ostdlib.Executor] which injects faults.
+func (t *StdlibRepoTransactor) GetExecutor(ctx context.Context) ostdlib.Executor {
+	return &SQLExecutor{
+		executor: t.transactor.GetExecutor(ctx),
+		injector: t.injector,
+	}
+}
+
+// SqlxRepoTransactor wraps [osqlx.Executor] of the decorated transactor with [SQLExecutor].
+type SqlxRepoTransactor struct {
+	transactor sqlxRepoTransactor
+	injector   *Injector
+}
+
+// NewSqlxRepoTransactor returns new SqlxRepoTransactor.
+func NewSqlxRepoTransactor(transactor sqlxRepoTransactor, injector *Injector) *SqlxRepoTransactor {
+	return &SqlxRepoTransactor{
+		transactor: transactor,
+		injector:   injector,
+	}
+}
+
+// GetExecutor returns [osqlx.Executor] which injects faults.
+func (t *SqlxRepoTransactor) GetExecutor(ctx context.Context) osqlx.Executor {
+	return &SQLExecutor{
+		executor: t.transactor.GetExecutor(ctx),
+		injector: t.injector,
+	}
+}
+
+// SQLExecutor implements [ostdlib.Executor] and [osqlx.Executor] and injects faults
+// at the [BeforeExec] and the [AfterExec] points of the statements.
+//
+// QueryRow and QueryRowContext do not reach the points, since [sql.Row] can't carry the injected error.
+//
+// [Rule.DropConn] is applied within a transaction ([sql.Tx] or [sqlx.Tx]) only,
+// since [sql.DB] would terminate an arbitrary connection of the pool instead of the statement's one.
+// Outside a transaction the statement fails with [ErrDropConnOutsideTx] and isn't executed.
+type SQLExecutor struct {
+	executor ostdlib.Executor
+	injector *Injector
+}
+
+func (e *SQLExecutor) dropConn(ctx context.Context) func() error {
+	return func() error {
+		switch e.executor.(type) {
+		case *sql.Tx, *sqlx.Tx:
+		default:
+			return fmt.Errorf("%w: %T", ErrDropConnOutsideTx, e.executor)
+		}
+		_, err := e.executor.ExecContext(ctx, dropConnQuery)
+		return err
+	}
+}
+
+func (e *SQLExecutor) Exec(query string, args ...any) (sql.Result, error) {
+	ctx := context.Background()
+	return execute(ctx, e.injector, query, e.dropConn(ctx), func() (sql.Result, error) {
+		return e.executor.Exec(query, args...)
+	})
+}
+
+func (e *SQLExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return execute(ctx, e.injector, query, e.dropConn(ctx), func() (sql.Result, error) {
+		return e.executor.ExecContext(ctx, query, args...)
+	})
+}
+
+func (e *SQLExecutor) Query(query string, args ...any) (*sql.Rows, error) {
+	ctx := context.Background()
+	return execute(ctx, e.injector, query, e.dropConn(ctx), func() (*sql.Rows, error) {
+		return e.executor.Query(query, args...)
+	})
+}
+
+func (e *SQLExecutor) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return execute(ctx, e.injector, query, e.dropConn(ctx), func() (*sql.Rows, error) {
+		return e.executor.QueryContext(ctx, query, args...)
+	})
+}
+
+func (e *SQLExecutor) QueryRow(query string, args ...any) *sql.Row {
+	return e.executor.QueryRow(query, args...)
+}
+
+func (e *SQLExecutor) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return e.executor.QueryRowContext(ctx, query, args...)
+}
+
+func (e *SQLExecutor) Prepare(query string) (*sql.Stmt, error) {
+	ctx := context.Background()
+	return execute(ctx, e.injector, query, e.dropConn(ctx), func() (*sql.Stmt, error) {
+		return e.executor.Prepare(query)
+	})
+}
+
+func (e *SQLExecutor) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return execute(ctx, e.injector, query, e.dropConn(ctx), func() (*sql.Stmt, error) {
+		return e.executor.PrepareContext(ctx, query)
+	})
+}
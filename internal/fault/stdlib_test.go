@@ -0,0 +1,65 @@
+package fault
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	insertQuery = `INSERT INTO text (val) VALUES ($1)`
+	textRecord  = "text_A"
+)
+
+func newSqlMock(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, mock.ExpectationsWereMet())
+		mock.ExpectClose()
+		assert.NoError(t, db.Close())
+	})
+	return db, mock
+}
+
+func Test_SQLExecutor(t *testing.T) {
+	t.Run("drop_conn_within_tx", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			db, mock = newSqlMock(t)
+			connErr  = sqlmock.ErrCancelled
+			injector = NewInjector(Rule{Point: BeforeExec, DropConn: true})
+		)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(dropConnQuery).WillReturnError(connErr)
+		mock.ExpectRollback()
+
+		tx, err := db.BeginTx(ctx, nil)
+		assert.NoError(t, err)
+
+		executor := SQLExecutor{executor: tx, injector: injector}
+		_, err = executor.ExecContext(ctx, insertQuery, textRecord)
+		assert.ErrorIs(t, err, connErr)
+		assert.NotErrorIs(t, err, ErrDropConnOutsideTx)
+
+		assert.NoError(t, tx.Rollback())
+	})
+	t.Run("drop_conn_outside_tx", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			db, _    = newSqlMock(t)
+			injector = NewInjector(Rule{Point: BeforeExec, DropConn: true})
+		)
+
+		// neither the statement nor the connection's termination is executed on the pool.
+		executor := SQLExecutor{executor: db, injector: injector}
+		_, err := executor.ExecContext(ctx, insertQuery, textRecord)
+		assert.ErrorIs(t, err, ErrDropConnOutsideTx)
+		assert.Equal(t, 1, injector.Calls(BeforeExec))
+	})
+}
@@ -0,0 +1,66 @@
+package fault
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kozmod/oniontx"
+)
+
+type (
+	transactor interface {
+		WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error)
+	}
+)
+
+// nestedKey marks nested calls of the particular Transactor,
+// so a Transactor called within another one still injects its faults.
+type nestedKey struct {
+	transactor *Transactor
+}
+
+// Transactor decorates a transactor and injects faults at the [Commit] and the [Rollback] points.
+//
+// Only the highest level WithinTx call reaches the points, since nested calls do not commit or roll back the transaction.
+// The fault at the [Commit] point fails the transaction instead of the commit, so the transaction is rolled back
+// and the error wraps [oniontx.ErrCommitFailed]. The fault at the [Rollback] point is injected after the real rollback,
+// so the connection is not left within the transaction and the error wraps [oniontx.ErrRollbackFailed].
+type Transactor struct {
+	transactor transactor
+	injector   *Injector
+}
+
+// NewTransactor returns new Transactor.
+func NewTransactor(transactor transactor, injector *Injector) *Transactor {
+	return &Transactor{
+		transactor: transactor,
+		injector:   injector,
+	}
+}
+
+// WithinTx calls WithinTx of the decorated transactor and injects faults.
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if nested, _ := ctx.Value(nestedKey{transactor: t}).(bool); nested {
+		return t.transactor.WithinTx(ctx, fn)
+	}
+
+	fnFailed := false
+	err = t.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		fnFailed = true
+		err := fn(context.WithValue(ctx, nestedKey{transactor: t}, true))
+		if fnFailed = err != nil; fnFailed {
+			return err
+		}
+		if err = t.injector.Inject(ctx, Commit, ""); err != nil {
+			return errors.Join(err, oniontx.ErrCommitFailed)
+		}
+		return nil
+	})
+
+	if fnFailed {
+		if rbErr := t.injector.Inject(ctx, Rollback, ""); rbErr != nil {
+			return errors.Join(err, rbErr, oniontx.ErrRollbackFailed)
+		}
+	}
+	return err
+}
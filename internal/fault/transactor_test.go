@@ -0,0 +1,96 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kozmod/oniontx"
+	"github.com/stretchr/testify/assert"
+)
+
+// transactorFunc calls fn and returns the error of fn, like the real transactor's rollback.
+type transactorFunc func(ctx context.Context, fn func(ctx context.Context) error) error
+
+func (f transactorFunc) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return f(ctx, fn)
+}
+
+func Test_Transactor(t *testing.T) {
+	var (
+		expErr = fmt.Errorf("some_error")
+		base   = transactorFunc(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	)
+
+	t.Run("commit", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			injector   = NewInjector(Rule{Point: Commit, Err: expErr})
+			transactor = NewTransactor(base, injector)
+		)
+
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			return transactor.WithinTx(ctx, func(ctx context.Context) error {
+				return nil
+			})
+		})
+		assert.ErrorIs(t, err, expErr)
+		assert.ErrorIs(t, err, oniontx.ErrCommitFailed)
+		assert.Equal(t, 1, injector.Calls(Commit))
+		assert.Equal(t, 0, injector.Calls(Rollback))
+	})
+	t.Run("rollback", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			fnErr      = fmt.Errorf("fn_error")
+			injector   = NewInjector(Rule{Point: Rollback, Err: expErr})
+			transactor = NewTransactor(base, injector)
+		)
+
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			return transactor.WithinTx(ctx, func(ctx context.Context) error {
+				return fnErr
+			})
+		})
+		assert.ErrorIs(t, err, fnErr)
+		assert.ErrorIs(t, err, expErr)
+		assert.ErrorIs(t, err, oniontx.ErrRollbackFailed)
+		assert.Equal(t, 0, injector.Calls(Commit))
+		assert.Equal(t, 1, injector.Calls(Rollback))
+	})
+	t.Run("without_faults", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			injector   = NewInjector()
+			transactor = NewTransactor(base, injector)
+		)
+
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, injector.Calls(Commit))
+	})
+	t.Run("different_transactors_injected", func(t *testing.T) {
+		var (
+			ctx           = context.Background()
+			outerInjector = NewInjector()
+			innerInjector = NewInjector(Rule{Point: Commit, Err: expErr})
+			outer         = NewTransactor(base, outerInjector)
+			inner         = NewTransactor(base, innerInjector)
+		)
+
+		err := outer.WithinTx(ctx, func(ctx context.Context) error {
+			return inner.WithinTx(ctx, func(ctx context.Context) error {
+				return nil
+			})
+		})
+		assert.ErrorIs(t, err, expErr)
+		assert.ErrorIs(t, err, oniontx.ErrCommitFailed)
+		assert.Equal(t, 1, innerInjector.Calls(Commit))
+		assert.Equal(t, 0, outerInjector.Calls(Commit))
+		assert.Equal(t, 1, outerInjector.Calls(Rollback))
+	})
+}
@@ -0,0 +1,98 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ogorm "github.com/kozmod/oniontx/gorm"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/testdb"
+	"github.com/kozmod/oniontx-examples/internal/usecase"
+)
+
+func Test_UseCase_Cancel(t *testing.T) {
+	t.Parallel()
+
+	var (
+		leaks  = testdb.NewLeakDetector(t)
+		schema = testdb.NewSchema(t, leaks.ConnectionString())
+		db     = ConnectDB(t, schema.ConnectionString())
+	)
+
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+
+	assertUsable := func(t *testing.T) {
+		assert.Eventually(t, func() bool {
+			return sqlDB.Stats().InUse == 0
+		}, time.Second, 10*time.Millisecond)
+		assert.NoError(t, sqlDB.PingContext(context.Background()))
+	}
+
+	t.Run("cancel_between_inserts", func(t *testing.T) {
+		leaks.Watch(t)
+
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			transactor  = ogorm.NewTransactor(db)
+			repositoryA = NewTextRepository(transactor)
+			repositoryB = testdb.InterruptTextRepository{
+				Repository: repositoryA.Raw(),
+				Interrupt:  func(context.Context) { cancel() },
+			}
+			useCase = usecase.NewUseCase(repositoryA.Raw(), repositoryB, transactor)
+		)
+		defer cancel()
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		{
+			records, err := GetTextRecords(db)
+			assert.NoError(t, err)
+			assert.Len(t, records, 0)
+			assertUsable(t)
+		}
+
+		t.Cleanup(func() {
+			err = ClearDB(db)
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("deadline_between_inserts", func(t *testing.T) {
+		leaks.Watch(t)
+
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+			transactor  = ogorm.NewTransactor(db)
+			repositoryA = NewTextRepository(transactor)
+			repositoryB = testdb.InterruptTextRepository{
+				Repository: repositoryA.Raw(),
+				Interrupt:  func(ctx context.Context) { <-ctx.Done() },
+			}
+			useCases = usecase.NewUseCases(
+				transactor,
+				usecase.NewStep("A", usecase.NewUseCase(repositoryA.Raw(), repositoryB, transactor)),
+				usecase.NewStep("B", usecase.NewUseCase(repositoryA.Raw(), repositoryA.Raw(), transactor)),
+			)
+		)
+		defer cancel()
+
+		err := useCases.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		{
+			records, err := GetTextRecords(db)
+			assert.NoError(t, err)
+			assert.Len(t, records, 0)
+			assertUsable(t)
+		}
+
+		t.Cleanup(func() {
+			err = ClearDB(db)
+			assert.NoError(t, err)
+		})
+	})
+}
@@ -0,0 +1,72 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kozmod/oniontx"
+	ogorm "github.com/kozmod/oniontx/gorm"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/kozmod/oniontx-examples/internal/testdb"
+)
+
+func Test_TextRepository_Errors(t *testing.T) {
+	t.Parallel()
+
+	var (
+		leaks  = testdb.NewLeakDetector(t)
+		schema = testdb.NewSchema(t, leaks.ConnectionString())
+		dbs    = map[string]*gorm.DB{
+			"raw_errors": ConnectDB(t, schema.ConnectionString()),
+			"translated_errors": ConnectDB(t, schema.ConnectionString(), func(config *gorm.Config) {
+				config.TranslateError = true
+			}),
+		}
+	)
+
+	for name, db := range dbs {
+		t.Run(name, func(t *testing.T) {
+			for _, violation := range testdb.Violations() {
+				t.Run(violation.Name, func(t *testing.T) {
+					leaks.Watch(t)
+
+					var (
+						ctx        = context.Background()
+						transactor = ogorm.NewTransactor(db)
+						repository = NewTextRepository(transactor)
+					)
+
+					err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+						ex := transactor.GetExecutor(ctx)
+						for _, stmt := range violation.Setup {
+							err := ex.WithContext(ctx).Exec(stmt).Error
+							assert.NoError(t, err)
+						}
+						for _, val := range violation.Values {
+							err := repository.RawInsert(ctx, val)
+							if err != nil {
+								return err
+							}
+						}
+						return nil
+					})
+					assert.ErrorIs(t, err, violation.Err)
+					assert.ErrorIs(t, err, oniontx.ErrRollbackSuccess)
+
+					{
+						records, err := GetTextRecords(db)
+						assert.NoError(t, err)
+						assert.Len(t, records, 0)
+					}
+
+					t.Cleanup(func() {
+						err = ClearDB(db)
+						assert.NoError(t, err)
+					})
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package gorm
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/kozmod/oniontx"
+	ogorm "github.com/kozmod/oniontx/gorm"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+	"github.com/kozmod/oniontx-examples/internal/fault"
+	"github.com/kozmod/oniontx-examples/internal/testdb"
+	"github.com/kozmod/oniontx-examples/internal/usecase"
+)
+
+func Test_UseCase_Faults(t *testing.T) {
+	t.Parallel()
+
+	var (
+		leaks  = testdb.NewLeakDetector(t)
+		schema = testdb.NewSchema(t, leaks.ConnectionString())
+		db     = ConnectDB(t, schema.ConnectionString())
+	)
+
+	testCases := []struct {
+		name    string
+		timeout time.Duration
+		rules   []fault.Rule
+		expErrs []error
+	}{
+		{
+			name:    "nth_call",
+			timeout: time.Minute,
+			rules:   []fault.Rule{{Point: fault.BeforeExec, Nth: 2, Err: entity.ErrExpected}},
+			expErrs: []error{entity.ErrExpected, oniontx.ErrRollbackSuccess},
+		},
+		{
+			name:    "after_exec",
+			timeout: time.Minute,
+			rules:   []fault.Rule{{Point: fault.AfterExec, Nth: 2, Err: entity.ErrExpected}},
+			expErrs: []error{entity.ErrExpected, oniontx.ErrRollbackSuccess},
+		},
+		{
+			name:    "commit",
+			timeout: time.Minute,
+			rules:   []fault.Rule{{Point: fault.Commit, Err: entity.ErrExpected}},
+			expErrs: []error{entity.ErrExpected, oniontx.ErrCommitFailed},
+		},
+		{
+			name:    "rollback",
+			timeout: time.Minute,
+			rules: []fault.Rule{
+				{Point: fault.AfterExec, Nth: 1, Err: entity.ErrExpected},
+				{Point: fault.Rollback, Err: entity.ErrExpected},
+			},
+			expErrs: []error{entity.ErrExpected, oniontx.ErrRollbackFailed},
+		},
+		{
+			name:    "query",
+			timeout: time.Minute,
+			rules: []fault.Rule{{
+				Point: fault.BeforeExec,
+				Query: regexp.MustCompile(`^INSERT INTO text`),
+				Nth:   3,
+				Err:   entity.ErrExpected,
+			}},
+			expErrs: []error{entity.ErrExpected, oniontx.ErrRollbackSuccess},
+		},
+		{
+			name:    "drop_conn",
+			timeout: time.Minute,
+			rules:   []fault.Rule{{Point: fault.BeforeExec, Nth: 3, DropConn: true}},
+		},
+		{
+			name:    "latency",
+			timeout: 100 * time.Millisecond,
+			rules:   []fault.Rule{{Point: fault.BeforeExec, Nth: 2, Latency: time.Minute}},
+			expErrs: []error{context.DeadlineExceeded},
+		},
+	}
+
+	attachments := []struct {
+		name           string
+		newTransactor  func(t *testing.T, injector *fault.Injector) *ogorm.Transactor
+		repoTransactor func(transactor *ogorm.Transactor, injector *fault.Injector) repoTransactor
+	}{
+		{
+			name: "repo_transactor",
+			newTransactor: func(*testing.T, *fault.Injector) *ogorm.Transactor {
+				return ogorm.NewTransactor(db)
+			},
+			repoTransactor: func(transactor *ogorm.Transactor, injector *fault.Injector) repoTransactor {
+				return fault.NewGormRepoTransactor(transactor, injector)
+			},
+		},
+		{
+			name: "plugin",
+			newTransactor: func(t *testing.T, injector *fault.Injector) *ogorm.Transactor {
+				pluginDB := ConnectDB(t, schema.ConnectionString())
+				err := pluginDB.Use(fault.NewGormPlugin(injector))
+				assert.NoError(t, err)
+				return ogorm.NewTransactor(pluginDB)
+			},
+			repoTransactor: func(transactor *ogorm.Transactor, _ *fault.Injector) repoTransactor {
+				return transactor
+			},
+		},
+	}
+
+	for _, attachment := range attachments {
+		for _, tc := range testCases {
+			t.Run(attachment.name+"_"+tc.name, func(t *testing.T) {
+				leaks.Watch(t)
+
+				var (
+					ctx, cancel = context.WithTimeout(context.Background(), tc.timeout)
+					injector    = fault.NewInjector(tc.rules...)
+					transactor  = attachment.newTransactor(t, injector)
+					repository  = NewTextRepository(attachment.repoTransactor(transactor, injector)).Raw()
+					useCases    = usecase.NewUseCases(
+						fault.NewTransactor(transactor, injector),
+						usecase.NewStep("A", usecase.NewUseCase(repository, repository, transactor)),
+						usecase.NewStep("B", usecase.NewUseCase(repository, repository, transactor)),
+					)
+				)
+				defer cancel()
+
+				err := useCases.CreateTextRecords(ctx, textRecord)
+				assert.Error(t, err)
+				for _, expErr := range tc.expErrs {
+					assert.ErrorIs(t, err, expErr)
+				}
+
+				{
+					records, err := GetTextRecords(db)
+					assert.NoError(t, err)
+					assert.Len(t, records, 0)
+				}
+
+				t.Cleanup(func() {
+					err = ClearDB(db)
+					assert.NoError(t, err)
+				})
+			})
+		}
+	}
+}
@@ -1,22 +1,123 @@
 package gorm
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"sync/atomic"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kozmod/oniontx"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-
-	"github.com/kozmod/oniontx-examples/internal/entity"
+	"gorm.io/gorm/logger"
 )
 
-func ConnectDB(t *testing.T) *gorm.DB {
-	db, err := gorm.Open(postgres.Open(entity.ConnectionString), &gorm.Config{})
+func ConnectDB(t *testing.T, connString string, opts ...func(config *gorm.Config)) *gorm.DB {
+	var config gorm.Config
+	for _, opt := range opts {
+		opt(&config)
+	}
+	db, err := gorm.Open(postgres.Open(connString), &config)
 	assert.NoError(t, err)
 	return db
 }
 
+// NewSqlMock returns [gorm.DB] with the postgres dialector backed by [sqlmock.Sqlmock],
+// which matches the expected SQL exactly and in order.
+func NewSqlMock(t *testing.T, opts ...func(config *gorm.Config)) (*gorm.DB, sqlmock.Sqlmock) {
+	sqlDB, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+		sqlMock.ExpectClose()
+		assert.NoError(t, sqlDB.Close())
+	})
+
+	config := gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &config)
+	assert.NoError(t, err)
+	return db, sqlMock
+}
+
+// outerTxWrapper wraps the test's outer transaction ([gorm.DB]) and implements [oniontx.TxBeginner].
+// Each transaction begun by outerTxWrapper is a savepoint of the outer transaction.
+type outerTxWrapper struct {
+	*gorm.DB
+	seq atomic.Uint64
+}
+
+// BeginTx creates a savepoint, [sql.TxOptions] are ignored.
+func (w *outerTxWrapper) BeginTx(ctx context.Context, _ ...oniontx.Option[*sql.TxOptions]) (*savepointWrapper, error) {
+	name := fmt.Sprintf("sp_%d", w.seq.Add(1))
+	if err := w.DB.WithContext(ctx).SavePoint(name).Error; err != nil {
+		return nil, fmt.Errorf("savepoint [%s]: %w", name, err)
+	}
+	return &savepointWrapper{DB: w.DB, name: name}, nil
+}
+
+// savepointWrapper wraps the savepoint of the test's outer transaction ([gorm.DB]) and implements [oniontx.Tx].
+type savepointWrapper struct {
+	*gorm.DB
+	name string
+}
+
+// Rollback rolls back to the savepoint.
+func (w *savepointWrapper) Rollback(ctx context.Context) error {
+	if err := w.DB.WithContext(context.WithoutCancel(ctx)).RollbackTo(w.name).Error; err != nil {
+		return fmt.Errorf("rollback to savepoint [%s]: %w", w.name, err)
+	}
+	return nil
+}
+
+// Commit releases the savepoint, the changes stay in the test's outer transaction.
+//
+// Unlike the oniontx gorm Transactor, the error of the release is returned.
+func (w *savepointWrapper) Commit(ctx context.Context) error {
+	if err := w.DB.WithContext(ctx).Exec(`RELEASE SAVEPOINT ` + w.name).Error; err != nil {
+		return fmt.Errorf("release savepoint [%s]: %w", w.name, err)
+	}
+	return nil
+}
+
+// RollbackTransactor manages transactions as savepoints of the test's outer transaction ([gorm.DB]).
+type RollbackTransactor struct {
+	*oniontx.Transactor[*outerTxWrapper, *savepointWrapper, *sql.TxOptions]
+}
+
+// NewRollbackTransactor begins the test's outer transaction and returns RollbackTransactor bound to it.
+// The outer transaction is rolled back in the test's cleanup, so the test leaves no records.
+func NewRollbackTransactor(ctx context.Context, t *testing.T, db *gorm.DB) *RollbackTransactor {
+	tx := db.WithContext(ctx).Begin()
+	assert.NoError(t, tx.Error)
+
+	t.Cleanup(func() {
+		assert.NoError(t, tx.Rollback().Error)
+	})
+
+	var (
+		base       = outerTxWrapper{DB: tx}
+		operator   = oniontx.NewContextOperator[*outerTxWrapper, *savepointWrapper](&base)
+		transactor = oniontx.NewTransactor[*outerTxWrapper, *savepointWrapper, *sql.TxOptions](&base, operator)
+	)
+	return &RollbackTransactor{
+		Transactor: transactor,
+	}
+}
+
+// GetExecutor returns the test's outer transaction ([gorm.DB]), which is the executor of the savepoints too.
+func (t *RollbackTransactor) GetExecutor(_ context.Context) *gorm.DB {
+	return t.Transactor.TxBeginner().DB
+}
+
 func ClearDB(db *gorm.DB) error {
 	ex := db.Exec(`TRUNCATE TABLE text;`)
 	if ex.Error != nil {
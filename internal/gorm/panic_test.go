@@ -0,0 +1,93 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kozmod/oniontx"
+	ogorm "github.com/kozmod/oniontx/gorm"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+	"github.com/kozmod/oniontx-examples/internal/recovery"
+	"github.com/kozmod/oniontx-examples/internal/testdb"
+	"github.com/kozmod/oniontx-examples/internal/usecase"
+)
+
+func Test_UseCase_Panic(t *testing.T) {
+	t.Parallel()
+
+	var (
+		leaks  = testdb.NewLeakDetector(t)
+		schema = testdb.NewSchema(t, leaks.ConnectionString())
+		db     = ConnectDB(t, schema.ConnectionString())
+	)
+
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+
+	t.Run("panic_and_rollback", func(t *testing.T) {
+		leaks.Watch(t)
+
+		var (
+			ctx         = context.Background()
+			transactor  = ogorm.NewTransactor(db)
+			repositoryA = NewTextRepository(transactor)
+			useCase     = usecase.NewUseCase(repositoryA.Raw(), testdb.PanicTextRepository{}, transactor)
+		)
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, oniontx.ErrRollbackSuccess)
+		assert.ErrorContains(t, err, testdb.PanicValue)
+
+		{
+			records, err := GetTextRecords(db)
+			assert.NoError(t, err)
+			assert.Len(t, records, 0)
+			assert.Zero(t, sqlDB.Stats().InUse)
+		}
+
+		t.Cleanup(func() {
+			err = ClearDB(db)
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("recovered_panic_and_rollback", func(t *testing.T) {
+		leaks.Watch(t)
+
+		var (
+			ctx         = context.Background()
+			transactor  = ogorm.NewTransactor(db)
+			recovered   = recovery.NewTransactor(transactor)
+			repositoryA = NewTextRepository(transactor)
+			useCases    = usecase.NewUseCases(
+				recovered,
+				usecase.NewStep("A", usecase.NewUseCase(repositoryA.Raw(), repositoryA.Raw(), recovered)),
+				usecase.NewStep("B", usecase.NewUseCase(repositoryA.Raw(), testdb.PanicTextRepository{}, recovered)),
+			)
+		)
+
+		err := useCases.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrPanicInTx)
+		assert.ErrorIs(t, err, oniontx.ErrRollbackSuccess)
+
+		var panicErr *entity.PanicError
+		if assert.True(t, errors.As(err, &panicErr)) {
+			assert.Equal(t, testdb.PanicValue, panicErr.Value)
+			assert.Contains(t, string(panicErr.Stack), "PanicTextRepository.Insert")
+		}
+
+		{
+			records, err := GetTextRecords(db)
+			assert.NoError(t, err)
+			assert.Len(t, records, 0)
+			assert.Zero(t, sqlDB.Stats().InUse)
+		}
+
+		t.Cleanup(func() {
+			err = ClearDB(db)
+			assert.NoError(t, err)
+		})
+	})
+}
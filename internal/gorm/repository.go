@@ -2,6 +2,7 @@ package gorm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"gorm.io/gorm"
@@ -24,37 +25,61 @@ func (t *Text) TableName() string {
 }
 
 type TextRepository struct {
-	transactor    repoTransactor
-	errorExpected bool
+	transactor repoTransactor
 }
 
-func NewTextRepository(transactor repoTransactor, errorExpected bool) *TextRepository {
+func NewTextRepository(transactor repoTransactor) *TextRepository {
 	return &TextRepository{
-		transactor:    transactor,
-		errorExpected: errorExpected,
+		transactor: transactor,
 	}
 }
 
 func (r *TextRepository) RawInsert(ctx context.Context, val string) error {
-	if r.errorExpected {
-		return entity.ErrExpected
-	}
 	ex := r.transactor.GetExecutor(ctx)
-	ex = ex.Exec(`INSERT INTO text (val) VALUES ($1)`, val)
+	ex = ex.WithContext(ctx).Exec(`INSERT INTO text (val) VALUES ($1)`, val)
 	if ex.Error != nil {
-		return fmt.Errorf("gorm repository - raw insert: %w", ex.Error)
+		return fmt.Errorf("gorm repository - raw insert: %w", mapError(ex.Error))
 	}
 	return nil
 }
 
 func (r *TextRepository) Insert(ctx context.Context, text Text) error {
-	if r.errorExpected {
-		return entity.ErrExpected
-	}
 	ex := r.transactor.GetExecutor(ctx)
-	ex = ex.Create(text)
+	ex = ex.WithContext(ctx).Create(text)
 	if ex.Error != nil {
-		return fmt.Errorf("gorm repository - raw insert: %w", ex.Error)
+		return fmt.Errorf("gorm repository - raw insert: %w", mapError(ex.Error))
 	}
 	return nil
 }
+
+// RawTextRepository inserts text records of the [TextRepository] by the raw SQL.
+type RawTextRepository struct {
+	repository *TextRepository
+}
+
+// Raw returns the [RawTextRepository] view of the repository.
+func (r *TextRepository) Raw() *RawTextRepository {
+	return &RawTextRepository{
+		repository: r,
+	}
+}
+
+func (r *RawTextRepository) Insert(ctx context.Context, val string) error {
+	return r.repository.RawInsert(ctx, val)
+}
+
+// translatedErrors maps errors translated by the dialector ([gorm.Config.TranslateError]) to the domain errors,
+// since the translated errors lose the Postgres SQLSTATE code.
+var translatedErrors = map[error]error{
+	gorm.ErrDuplicatedKey:      entity.ErrDuplicate,
+	gorm.ErrForeignKeyViolated: entity.ErrForeignKey,
+}
+
+func mapError(err error) error {
+	for translatedErr, domainErr := range translatedErrors {
+		if errors.Is(err, translatedErr) {
+			return fmt.Errorf("%w: %w", domainErr, err)
+		}
+	}
+	return entity.MapError(err)
+}
@@ -0,0 +1,239 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kozmod/oniontx"
+	ogorm "github.com/kozmod/oniontx/gorm"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+	"github.com/kozmod/oniontx-examples/internal/usecase"
+)
+
+const (
+	rawInsertQuery = `INSERT INTO text (val) VALUES ($1)`
+	insertQuery    = `INSERT INTO "text" ("val") VALUES ($1)`
+)
+
+func Test_TextRepository_SqlMock(t *testing.T) {
+	t.Run("raw_insert", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			db, mock   = NewSqlMock(t)
+			repository = NewTextRepository(ogorm.NewTransactor(db))
+		)
+
+		mock.ExpectExec(rawInsertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repository.RawInsert(ctx, textRecord)
+		assert.NoError(t, err)
+	})
+	t.Run("insert", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			db, mock   = NewSqlMock(t)
+			repository = NewTextRepository(ogorm.NewTransactor(db))
+		)
+
+		// gorm wraps the single Create into the default transaction.
+		mock.ExpectBegin()
+		mock.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repository.Insert(ctx, Text{Val: textRecord})
+		assert.NoError(t, err)
+	})
+	t.Run("insert_skip_default_transaction", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			db, mock = NewSqlMock(t, func(config *gorm.Config) {
+				config.SkipDefaultTransaction = true
+			})
+			repository = NewTextRepository(ogorm.NewTransactor(db))
+		)
+
+		mock.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repository.Insert(ctx, Text{Val: textRecord})
+		assert.NoError(t, err)
+	})
+}
+
+func Test_UseCase_SqlMock(t *testing.T) {
+	t.Run("success_create", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			db, mock   = NewSqlMock(t)
+			transactor = ogorm.NewTransactor(db)
+			repository = NewTextRepository(transactor)
+			useCase    = usecase.NewUseCase(repository, repository, transactor)
+		)
+
+		// Create doesn't open the default transaction within the transaction of the transactor.
+		mock.ExpectBegin()
+		mock.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := useCase.CreateTextRecords(ctx, Text{Val: textRecord})
+		assert.NoError(t, err)
+	})
+	t.Run("error_and_rollback", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			db, mock   = NewSqlMock(t)
+			transactor = ogorm.NewTransactor(db)
+			repository = NewTextRepository(transactor)
+			useCase    = usecase.NewUseCase(repository.Raw(), repository.Raw(), transactor)
+		)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(rawInsertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(rawInsertQuery).WithArgs(textRecord).WillReturnError(entity.ErrExpected)
+		mock.ExpectRollback()
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.ErrorIs(t, err, oniontx.ErrRollbackSuccess)
+	})
+	t.Run("commit_error_is_ignored", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			db, mock   = NewSqlMock(t)
+			transactor = ogorm.NewTransactor(db)
+			repository = NewTextRepository(transactor)
+			useCase    = usecase.NewUseCase(repository.Raw(), repository.Raw(), transactor)
+		)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(rawInsertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(rawInsertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit().WillReturnError(entity.ErrExpected)
+
+		// ogorm doesn't return the error of the commit ([gorm.DB.Commit] result is discarded).
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+	})
+	t.Run("rollback_error_is_ignored", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			db, mock   = NewSqlMock(t)
+			transactor = ogorm.NewTransactor(db)
+			repository = NewTextRepository(transactor)
+			useCase    = usecase.NewUseCase(repository.Raw(), repository.Raw(), transactor)
+		)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(rawInsertQuery).WithArgs(textRecord).WillReturnError(entity.ErrExpected)
+		mock.ExpectRollback().WillReturnError(entity.ErrExpected)
+
+		// ogorm doesn't return the error of the rollback ([gorm.DB.Rollback] result is discarded),
+		// so the failed rollback is reported as the successful one.
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.ErrorIs(t, err, oniontx.ErrRollbackSuccess)
+		assert.NotErrorIs(t, err, oniontx.ErrRollbackFailed)
+	})
+}
+
+func Test_UseCases_SqlMock(t *testing.T) {
+	t.Run("success_create", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			db, mock   = NewSqlMock(t)
+			transactor = ogorm.NewTransactor(db)
+			repository = NewTextRepository(transactor)
+			useCases   = usecase.NewUseCases(
+				transactor,
+				usecase.NewStep("A", usecase.NewUseCase(repository.Raw(), repository.Raw(), transactor)),
+				usecase.NewStep("B", usecase.NewUseCase(repository.Raw(), repository.Raw(), transactor)),
+			)
+		)
+
+		// only the root transaction begins and commits.
+		mock.ExpectBegin()
+		for i := 0; i < 4; i++ {
+			mock.ExpectExec(rawInsertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		mock.ExpectCommit()
+
+		err := useCases.CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+	})
+	t.Run("error_and_rollback", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			db, mock   = NewSqlMock(t)
+			transactor = ogorm.NewTransactor(db)
+			repository = NewTextRepository(transactor)
+			useCases   = usecase.NewUseCases(
+				transactor,
+				usecase.NewStep("A", usecase.NewUseCase(repository.Raw(), repository.Raw(), transactor)),
+				usecase.NewStep("B", usecase.NewUseCase(repository.Raw(), repository.Raw(), transactor)),
+			)
+		)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(rawInsertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(rawInsertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(rawInsertQuery).WithArgs(textRecord).WillReturnError(entity.ErrExpected)
+		mock.ExpectRollback()
+
+		err := useCases.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.ErrorContains(t, err, "text usecase B")
+		assert.ErrorIs(t, err, oniontx.ErrRollbackSuccess)
+	})
+}
+
+func Test_RollbackTransactor_SqlMock(t *testing.T) {
+	t.Run("release_savepoint", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			db, mock = NewSqlMock(t)
+		)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`SAVEPOINT sp_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(rawInsertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(rawInsertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`RELEASE SAVEPOINT sp_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		var (
+			transactor = NewRollbackTransactor(ctx, t, db)
+			repository = NewTextRepository(transactor)
+			useCase    = usecase.NewUseCase(repository.Raw(), repository.Raw(), transactor)
+		)
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+	})
+	t.Run("rollback_to_savepoint", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			db, mock = NewSqlMock(t)
+		)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`SAVEPOINT sp_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(rawInsertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(rawInsertQuery).WithArgs(textRecord).WillReturnError(entity.ErrExpected)
+		mock.ExpectExec(`ROLLBACK TO SAVEPOINT sp_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		var (
+			transactor = NewRollbackTransactor(ctx, t, db)
+			repository = NewTextRepository(transactor)
+			useCase    = usecase.NewUseCase(repository.Raw(), repository.Raw(), transactor)
+		)
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.ErrorIs(t, err, oniontx.ErrRollbackSuccess)
+	})
+}
@@ -8,6 +8,9 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/kozmod/oniontx-examples/internal/entity"
+	"github.com/kozmod/oniontx-examples/internal/fault"
+	"github.com/kozmod/oniontx-examples/internal/testdb"
+	"github.com/kozmod/oniontx-examples/internal/usecase"
 )
 
 const (
@@ -15,17 +18,23 @@ const (
 )
 
 func Test_UseCase_CreateTextRecords(t *testing.T) {
+	t.Parallel()
+
 	var (
-		db = ConnectDB(t)
+		leaks  = testdb.NewLeakDetector(t)
+		schema = testdb.NewSchema(t, leaks.ConnectionString())
+		db     = ConnectDB(t, schema.ConnectionString())
 	)
 
 	t.Run("success_create", func(t *testing.T) {
+		leaks.Watch(t)
+
 		var (
 			ctx         = context.Background()
 			transactor  = ogorm.NewTransactor(db)
-			repositoryA = NewTextRepository(transactor, false)
-			repositoryB = NewTextRepository(transactor, false)
-			useCase     = NewUseCase(repositoryA, repositoryB, transactor)
+			repositoryA = NewTextRepository(transactor)
+			repositoryB = NewTextRepository(transactor)
+			useCase     = usecase.NewUseCase(repositoryA.Raw(), repositoryB.Raw(), transactor)
 		)
 
 		err := useCase.CreateTextRecords(ctx, textRecord)
@@ -46,12 +55,15 @@ func Test_UseCase_CreateTextRecords(t *testing.T) {
 		})
 	})
 	t.Run("error_and_rollback", func(t *testing.T) {
+		leaks.Watch(t)
+
 		var (
 			ctx         = context.Background()
 			transactor  = ogorm.NewTransactor(db)
-			repositoryA = NewTextRepository(transactor, false)
-			repositoryB = NewTextRepository(transactor, true)
-			useCase     = NewUseCase(repositoryA, repositoryB, transactor)
+			repositoryA = NewTextRepository(transactor)
+			injector    = fault.NewInjector(fault.Rule{Point: fault.BeforeExec, Err: entity.ErrExpected})
+			repositoryB = NewTextRepository(fault.NewGormRepoTransactor(transactor, injector))
+			useCase     = usecase.NewUseCase(repositoryA.Raw(), repositoryB.Raw(), transactor)
 		)
 
 		err := useCase.CreateTextRecords(ctx, textRecord)
@@ -73,23 +85,29 @@ func Test_UseCase_CreateTextRecords(t *testing.T) {
 }
 
 func Test_UseCase_CreateText(t *testing.T) {
+	t.Parallel()
+
 	var (
-		db = ConnectDB(t)
+		leaks  = testdb.NewLeakDetector(t)
+		schema = testdb.NewSchema(t, leaks.ConnectionString())
+		db     = ConnectDB(t, schema.ConnectionString())
 
 		text = Text{
 			Val: textRecord,
 		}
 	)
 	t.Run("success_create", func(t *testing.T) {
+		leaks.Watch(t)
+
 		var (
 			ctx         = context.Background()
 			transactor  = ogorm.NewTransactor(db)
-			repositoryA = NewTextRepository(transactor, false)
-			repositoryB = NewTextRepository(transactor, false)
-			useCase     = NewUseCase(repositoryA, repositoryB, transactor)
+			repositoryA = NewTextRepository(transactor)
+			repositoryB = NewTextRepository(transactor)
+			useCase     = usecase.NewUseCase(repositoryA, repositoryB, transactor)
 		)
 
-		err := useCase.CreateText(ctx, text)
+		err := useCase.CreateTextRecords(ctx, text)
 		assert.NoError(t, err)
 
 		{
@@ -107,15 +125,18 @@ func Test_UseCase_CreateText(t *testing.T) {
 		})
 	})
 	t.Run("error_and_rollback", func(t *testing.T) {
+		leaks.Watch(t)
+
 		var (
 			ctx         = context.Background()
 			transactor  = ogorm.NewTransactor(db)
-			repositoryA = NewTextRepository(transactor, false)
-			repositoryB = NewTextRepository(transactor, true)
-			useCase     = NewUseCase(repositoryA, repositoryB, transactor)
+			repositoryA = NewTextRepository(transactor)
+			injector    = fault.NewInjector(fault.Rule{Point: fault.BeforeExec, Err: entity.ErrExpected})
+			repositoryB = NewTextRepository(fault.NewGormRepoTransactor(transactor, injector))
+			useCase     = usecase.NewUseCase(repositoryA, repositoryB, transactor)
 		)
 
-		err := useCase.CreateText(ctx, text)
+		err := useCase.CreateTextRecords(ctx, text)
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, entity.ErrExpected)
 
@@ -134,20 +155,26 @@ func Test_UseCase_CreateText(t *testing.T) {
 }
 
 func Test_UseCases(t *testing.T) {
+	t.Parallel()
+
 	var (
-		db = ConnectDB(t)
+		leaks  = testdb.NewLeakDetector(t)
+		schema = testdb.NewSchema(t, leaks.ConnectionString())
+		db     = ConnectDB(t, schema.ConnectionString())
 	)
 	t.Run("single_repository", func(t *testing.T) {
 		t.Run("success_create", func(t *testing.T) {
+			leaks.Watch(t)
+
 			var (
 				ctx         = context.Background()
 				transactor  = ogorm.NewTransactor(db)
-				repositoryA = NewTextRepository(transactor, false)
-				repositoryB = NewTextRepository(transactor, false)
-				useCases    = NewUseCases(
-					NewUseCase(repositoryA, repositoryB, transactor),
-					NewUseCase(repositoryA, repositoryB, transactor),
+				repositoryA = NewTextRepository(transactor)
+				repositoryB = NewTextRepository(transactor)
+				useCases    = usecase.NewUseCases(
 					transactor,
+					usecase.NewStep("A", usecase.NewUseCase(repositoryA.Raw(), repositoryB.Raw(), transactor)),
+					usecase.NewStep("B", usecase.NewUseCase(repositoryA.Raw(), repositoryB.Raw(), transactor)),
 				)
 			)
 
@@ -169,15 +196,18 @@ func Test_UseCases(t *testing.T) {
 			})
 		})
 		t.Run("error_and_rollback", func(t *testing.T) {
+			leaks.Watch(t)
+
 			var (
 				ctx         = context.Background()
 				transactor  = ogorm.NewTransactor(db)
-				repositoryA = NewTextRepository(transactor, false)
-				repositoryB = NewTextRepository(transactor, true)
-				useCases    = NewUseCases(
-					NewUseCase(repositoryA, repositoryB, transactor),
-					NewUseCase(repositoryA, repositoryB, transactor),
+				repositoryA = NewTextRepository(transactor)
+				injector    = fault.NewInjector(fault.Rule{Point: fault.BeforeExec, Err: entity.ErrExpected})
+				repositoryB = NewTextRepository(fault.NewGormRepoTransactor(transactor, injector))
+				useCases    = usecase.NewUseCases(
 					transactor,
+					usecase.NewStep("A", usecase.NewUseCase(repositoryA.Raw(), repositoryB.Raw(), transactor)),
+					usecase.NewStep("B", usecase.NewUseCase(repositoryA.Raw(), repositoryB.Raw(), transactor)),
 				)
 			)
 
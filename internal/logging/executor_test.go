@@ -0,0 +1,121 @@
+package logging
+
+import (
+	"context"
+	"testing"
+
+	opgx "github.com/kozmod/oniontx/pgx"
+	ostdlib "github.com/kozmod/oniontx/stdlib"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+	pgxexample "github.com/kozmod/oniontx-examples/internal/pgx"
+	stdlibexample "github.com/kozmod/oniontx-examples/internal/stdlib"
+	"github.com/kozmod/oniontx-examples/internal/testdb"
+	"github.com/kozmod/oniontx-examples/internal/usecase"
+)
+
+const (
+	textRecord = "text_A"
+)
+
+func Test_StdlibRepoTransactor(t *testing.T) {
+	t.Parallel()
+
+	var (
+		schema = testdb.NewSchema(t, entity.ConnectionString)
+		db     = ConnectDB(t, schema.ConnectionString())
+	)
+
+	t.Cleanup(func() {
+		err := db.Close()
+		assert.NoError(t, err)
+	})
+
+	t.Run("statements_with_tx_id", func(t *testing.T) {
+		var (
+			ctx         = context.Background()
+			logger, buf = NewLogger()
+			transactor  = ostdlib.NewTransactor(db)
+			repoTx      = NewStdlibRepoTransactor(transactor, logger)
+			useCase     = usecase.NewUseCase(
+				stdlibexample.NewTextRepository(repoTx),
+				stdlibexample.NewTextRepository(repoTx),
+				NewTransactor(transactor, logger),
+			)
+		)
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+		AssertStatementRecords(t, Records(t, buf), 2)
+
+		t.Cleanup(func() {
+			err = ClearDB(db)
+			assert.NoError(t, err)
+		})
+	})
+}
+
+func Test_PgxRepoTransactor(t *testing.T) {
+	t.Parallel()
+
+	var (
+		globalCtx = context.Background()
+		schema    = testdb.NewSchema(t, entity.ConnectionString)
+		conn      = ConnectPgx(globalCtx, t, schema.ConnectionString())
+		db        = ConnectDB(t, schema.ConnectionString())
+	)
+
+	t.Cleanup(func() {
+		err := conn.Close(globalCtx)
+		assert.NoError(t, err)
+		err = db.Close()
+		assert.NoError(t, err)
+	})
+
+	t.Run("statements_with_tx_id", func(t *testing.T) {
+		var (
+			ctx         = context.Background()
+			logger, buf = NewLogger()
+			transactor  = opgx.NewTransactor(conn)
+			repoTx      = NewPgxRepoTransactor(transactor, logger)
+			useCase     = usecase.NewUseCase(
+				pgxexample.NewTextRepository(repoTx),
+				pgxexample.NewTextRepository(repoTx),
+				NewTransactor(transactor, logger),
+			)
+		)
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+		AssertStatementRecords(t, Records(t, buf), 2)
+
+		t.Cleanup(func() {
+			err = ClearDB(db)
+			assert.NoError(t, err)
+		})
+	})
+}
+
+// AssertStatementRecords asserts that all statements' records carry the transaction ID and contain no arguments' values.
+func AssertStatementRecords(t *testing.T, records []map[string]any, statements int) {
+	t.Helper()
+
+	if !assert.NotEmpty(t, records) {
+		return
+	}
+	txID := records[0][AttrTxID]
+	assert.NotEmpty(t, txID)
+
+	var count int
+	for _, record := range records {
+		assert.Equal(t, txID, record[AttrTxID])
+		if _, ok := record[AttrQuery]; !ok {
+			continue
+		}
+		count++
+		assert.Equal(t, []any{"string"}, record[AttrArgs])
+		assert.NotContains(t, record[AttrQuery], textRecord)
+	}
+	assert.Equal(t, statements, count)
+}
@@ -0,0 +1,77 @@
+package logging
+
+import (
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	gormPluginName = "oniontx:logging"
+	gormStartKey   = "oniontx:logging:start"
+)
+
+// GormPlugin implements [gorm.Plugin] and logs each SQL statement with redacted arguments.
+//
+// The logger is obtained from the [gorm.Statement] context (look at [FromContext]),
+// so a repository should pass [context.Context] with [gorm.DB.WithContext].
+type GormPlugin struct {
+	logger *slog.Logger
+}
+
+// NewGormPlugin returns new GormPlugin.
+func NewGormPlugin(logger *slog.Logger) *GormPlugin {
+	return &GormPlugin{
+		logger: logger,
+	}
+}
+
+// Name returns the plugin name.
+func (p *GormPlugin) Name() string {
+	return gormPluginName
+}
+
+// Initialize registers the plugin callbacks.
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	var (
+		callback = db.Callback()
+		before   = gormPluginName + ":before"
+		after    = gormPluginName + ":after"
+	)
+	for _, err := range []error{
+		callback.Create().Before("gorm:create").Register(before, p.before),
+		callback.Create().After("gorm:create").Register(after, p.after),
+		callback.Query().Before("gorm:query").Register(before, p.before),
+		callback.Query().After("gorm:query").Register(after, p.after),
+		callback.Update().Before("gorm:update").Register(before, p.before),
+		callback.Update().After("gorm:update").Register(after, p.after),
+		callback.Delete().Before("gorm:delete").Register(before, p.before),
+		callback.Delete().After("gorm:delete").Register(after, p.after),
+		callback.Row().Before("gorm:row").Register(before, p.before),
+		callback.Row().After("gorm:row").Register(after, p.after),
+		callback.Raw().Before("gorm:raw").Register(before, p.before),
+		callback.Raw().After("gorm:raw").Register(after, p.after),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *GormPlugin) before(db *gorm.DB) {
+	db.InstanceSet(gormStartKey, time.Now())
+}
+
+func (p *GormPlugin) after(db *gorm.DB) {
+	v, ok := db.InstanceGet(gormStartKey)
+	if !ok {
+		return
+	}
+	start, ok := v.(time.Time)
+	if !ok {
+		return
+	}
+	logStatement(db.Statement.Context, p.logger, db.Statement.SQL.String(), db.Statement.Vars, start, db.Error)
+}
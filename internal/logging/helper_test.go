@@ -0,0 +1,65 @@
+package logging
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/assert"
+)
+
+func ConnectDB(t *testing.T, connString string) *sql.DB {
+	connConfig, err := pgx.ParseConfig(connString)
+	assert.NoError(t, err)
+
+	connStr := stdlib.RegisterConnConfig(connConfig)
+	db, err := sql.Open("pgx", connStr)
+	assert.NoError(t, err)
+
+	err = db.Ping()
+	assert.NoError(t, err)
+
+	return db
+}
+
+func ConnectPgx(ctx context.Context, t *testing.T, connString string) *pgx.Conn {
+	conn, err := pgx.Connect(ctx, connString)
+	assert.NoError(t, err)
+	return conn
+}
+
+func ClearDB(db *sql.DB) error {
+	_, err := db.Exec("TRUNCATE TABLE text;")
+	if err != nil {
+		return fmt.Errorf("clear DB: %w", err)
+	}
+	return nil
+}
+
+// NewLogger returns JSON logger which writes all levels' records to the buffer.
+func NewLogger() (*slog.Logger, *bytes.Buffer) {
+	buf := new(bytes.Buffer)
+	return slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})), buf
+}
+
+// Records returns decoded records written by the logger from NewLogger.
+func Records(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var (
+		records []map[string]any
+		scanner = bufio.NewScanner(bytes.NewReader(buf.Bytes()))
+	)
+	for scanner.Scan() {
+		var record map[string]any
+		err := json.Unmarshal(scanner.Bytes(), &record)
+		assert.NoError(t, err)
+		records = append(records, record)
+	}
+	return records
+}
@@ -0,0 +1,61 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	AttrTxID    = "tx_id"
+	AttrElapsed = "elapsed"
+	AttrError   = "error"
+	AttrQuery   = "query"
+	AttrArgs    = "args"
+)
+
+type loggerKey struct{}
+
+// WithLogger returns new [context.Context] contains the logger as value.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger extracted from [context.Context] or `fallback`.
+//
+// The logger injected by [Transactor] carries the transaction ID.
+func FromContext(ctx context.Context, fallback *slog.Logger) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok && logger != nil {
+		return logger
+	}
+	if fallback != nil {
+		return fallback
+	}
+	return slog.Default()
+}
+
+// redact replaces arguments' values with their types.
+func redact(args []any) []string {
+	redacted := make([]string, 0, len(args))
+	for _, arg := range args {
+		redacted = append(redacted, fmt.Sprintf("%T", arg))
+	}
+	return redacted
+}
+
+func logStatement(ctx context.Context, fallback *slog.Logger, query string, args []any, start time.Time, err error) {
+	var (
+		logger = FromContext(ctx, fallback)
+		attrs  = []slog.Attr{
+			slog.String(AttrQuery, query),
+			slog.Any(AttrArgs, redact(args)),
+			slog.Duration(AttrElapsed, time.Since(start)),
+		}
+	)
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "sql statement", append(attrs, slog.Any(AttrError, err))...)
+		return
+	}
+	logger.LogAttrs(ctx, slog.LevelDebug, "sql statement", attrs...)
+}
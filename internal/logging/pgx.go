@@ -0,0 +1,75 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	opgx "github.com/kozmod/oniontx/pgx"
+)
+
+type (
+	pgxRepoTransactor interface {
+		GetExecutor(ctx context.Context) opgx.Executor
+	}
+)
+
+// PgxRepoTransactor wraps [opgx.Executor] of the decorated transactor with [PgxExecutor].
+type PgxRepoTransactor struct {
+	transactor pgxRepoTransactor
+	logger     *slog.Logger
+}
+
+// NewPgxRepoTransactor returns new PgxRepoTransactor.
+func NewPgxRepoTransactor(transactor pgxRepoTransactor, logger *slog.Logger) *PgxRepoTransactor {
+	return &PgxRepoTransactor{
+		transactor: transactor,
+		logger:     logger,
+	}
+}
+
+// GetExecutor returns [opgx.Executor] which logs each SQL statement.
+func (t *PgxRepoTransactor) GetExecutor(ctx context.Context) opgx.Executor {
+	return &PgxExecutor{
+		executor: t.transactor.GetExecutor(ctx),
+		logger:   t.logger,
+	}
+}
+
+// PgxExecutor implements [opgx.Executor] and logs each SQL statement with redacted arguments.
+//
+// The logger is obtained from [context.Context] (look at [FromContext]).
+type PgxExecutor struct {
+	executor opgx.Executor
+	logger   *slog.Logger
+}
+
+func (e *PgxExecutor) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	start := time.Now()
+	tag, err := e.executor.Exec(ctx, sql, arguments...)
+	logStatement(ctx, e.logger, sql, arguments, start, err)
+	return tag, err
+}
+
+func (e *PgxExecutor) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	start := time.Now()
+	rows, err := e.executor.Query(ctx, sql, args...)
+	logStatement(ctx, e.logger, sql, args, start, err)
+	return rows, err
+}
+
+func (e *PgxExecutor) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	start := time.Now()
+	row := e.executor.QueryRow(ctx, sql, args...)
+	logStatement(ctx, e.logger, sql, args, start, nil)
+	return row
+}
+
+func (e *PgxExecutor) Prepare(ctx context.Context, name, sql string) (*pgconn.StatementDescription, error) {
+	start := time.Now()
+	sd, err := e.executor.Prepare(ctx, name, sql)
+	logStatement(ctx, e.logger, sql, nil, start, err)
+	return sd, err
+}
@@ -0,0 +1,40 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+type (
+	repository[T any] interface {
+		Insert(ctx context.Context, val T) error
+	}
+)
+
+// TextRepository decorates a text repository and logs its errors.
+//
+// The logger is obtained from [context.Context] (look at [FromContext]),
+// so the records of the repository within [Transactor]'s transaction carry the transaction ID.
+type TextRepository[T any] struct {
+	repository repository[T]
+	logger     *slog.Logger
+}
+
+// NewTextRepository returns new TextRepository.
+//
+// `logger` is used when [context.Context] doesn't contain a logger.
+func NewTextRepository[T any](repository repository[T], logger *slog.Logger) *TextRepository[T] {
+	return &TextRepository[T]{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+// Insert calls Insert of the decorated repository and logs the error.
+func (r *TextRepository[T]) Insert(ctx context.Context, val T) error {
+	err := r.repository.Insert(ctx, val)
+	if err != nil {
+		FromContext(ctx, r.logger).ErrorContext(ctx, "text repository - insert", slog.Any(AttrError, err))
+	}
+	return err
+}
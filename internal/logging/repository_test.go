@@ -0,0 +1,68 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+	"github.com/kozmod/oniontx-examples/internal/memory"
+	"github.com/kozmod/oniontx-examples/internal/usecase"
+)
+
+// errTextRepository fails any insert with entity.ErrExpected.
+type errTextRepository struct{}
+
+func (errTextRepository) Insert(context.Context, string) error {
+	return entity.ErrExpected
+}
+
+func Test_TextRepository(t *testing.T) {
+	t.Run("error_with_tx_id", func(t *testing.T) {
+		var (
+			ctx         = context.Background()
+			logger, buf = NewLogger()
+			memoryTx    = memory.NewTransactor(memory.NewStore())
+			transactor  = NewTransactor(memoryTx, logger)
+			useCase     = usecase.NewUseCase(
+				NewTextRepository[string](memory.NewTextRepository(memoryTx), logger),
+				NewTextRepository[string](errTextRepository{}, logger),
+				transactor,
+			)
+		)
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+
+		records := Records(t, buf)
+		if !assert.Len(t, records, 3) {
+			return
+		}
+
+		txID := records[0][AttrTxID]
+		assert.NotEmpty(t, txID)
+		for i, msg := range []string{"tx begin", "text repository - insert", "tx rollback"} {
+			assert.Equal(t, msg, records[i][slog.MessageKey])
+			assert.Equal(t, txID, records[i][AttrTxID])
+		}
+		assert.Equal(t, slog.LevelError.String(), records[1][slog.LevelKey])
+		assert.Equal(t, entity.ErrExpected.Error(), records[1][AttrError])
+	})
+	t.Run("error_without_tx", func(t *testing.T) {
+		var (
+			ctx         = context.Background()
+			logger, buf = NewLogger()
+			repository  = NewTextRepository[string](errTextRepository{}, logger)
+		)
+
+		err := repository.Insert(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+
+		records := Records(t, buf)
+		if assert.Len(t, records, 1) {
+			assert.NotContains(t, records[0], AttrTxID)
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package logging
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	osqlx "github.com/kozmod/oniontx/sqlx"
+	ostdlib "github.com/kozmod/oniontx/stdlib"
+)
+
+type (
+	stdlibRepoTransactor interface {
+		GetExecutor(ctx context.Context) ostdlib.Executor
+	}
+
+	sqlxRepoTransactor interface {
+		GetExecutor(ctx context.Context) osqlx.Executor
+	}
+)
+
+// StdlibRepoTransactor wraps [ostdlib.Executor] of the decorated transactor with [SQLExecutor].
+type StdlibRepoTransactor struct {
+	transactor stdlibRepoTransactor
+	logger     *slog.Logger
+}
+
+// NewStdlibRepoTransactor returns new StdlibRepoTransactor.
+func NewStdlibRepoTransactor(transactor stdlibRepoTransactor, logger *slog.Logger) *StdlibRepoTransactor {
+	return &StdlibRepoTransactor{
+		transactor: transactor,
+		logger:     logger,
+	}
+}
+
+// GetExecutor returns [ostdlib.Executor] which logs each SQL statement.
+func (t *StdlibRepoTransactor) GetExecutor(ctx context.Context) ostdlib.Executor {
+	return &SQLExecutor{
+		executor: t.transactor.GetExecutor(ctx),
+		logger:   t.logger,
+	}
+}
+
+// SqlxRepoTransactor wraps [osqlx.Executor] of the decorated transactor with [SQLExecutor].
+type SqlxRepoTransactor struct {
+	transactor sqlxRepoTransactor
+	logger     *slog.Logger
+}
+
+// NewSqlxRepoTransactor returns new SqlxRepoTransactor.
+func NewSqlxRepoTransactor(transactor sqlxRepoTransactor, logger *slog.Logger) *SqlxRepoTransactor {
+	return &SqlxRepoTransactor{
+		transactor: transactor,
+		logger:     logger,
+	}
+}
+
+// GetExecutor returns [osqlx.Executor] which logs each SQL statement.
+func (t *SqlxRepoTransactor) GetExecutor(ctx context.Context) osqlx.Executor {
+	return &SQLExecutor{
+		executor: t.transactor.GetExecutor(ctx),
+		logger:   t.logger,
+	}
+}
+
+// SQLExecutor implements [ostdlib.Executor] and [osqlx.Executor] and logs each SQL statement with redacted arguments.
+//
+// The logger is obtained from [context.Context] (look at [FromContext]).
+type SQLExecutor struct {
+	executor ostdlib.Executor
+	logger   *slog.Logger
+}
+
+func (e *SQLExecutor) Exec(query string, args ...any) (sql.Result, error) {
+	start := time.Now()
+	res, err := e.executor.Exec(query, args...)
+	logStatement(context.Background(), e.logger, query, args, start, err)
+	return res, err
+}
+
+func (e *SQLExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	start := time.Now()
+	res, err := e.executor.ExecContext(ctx, query, args...)
+	logStatement(ctx, e.logger, query, args, start, err)
+	return res, err
+}
+
+func (e *SQLExecutor) Query(query string, args ...any) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := e.executor.Query(query, args...)
+	logStatement(context.Background(), e.logger, query, args, start, err)
+	return rows, err
+}
+
+func (e *SQLExecutor) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := e.executor.QueryContext(ctx, query, args...)
+	logStatement(ctx, e.logger, query, args, start, err)
+	return rows, err
+}
+
+func (e *SQLExecutor) QueryRow(query string, args ...any) *sql.Row {
+	start := time.Now()
+	row := e.executor.QueryRow(query, args...)
+	logStatement(context.Background(), e.logger, query, args, start, row.Err())
+	return row
+}
+
+func (e *SQLExecutor) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	start := time.Now()
+	row := e.executor.QueryRowContext(ctx, query, args...)
+	logStatement(ctx, e.logger, query, args, start, row.Err())
+	return row
+}
+
+func (e *SQLExecutor) Prepare(query string) (*sql.Stmt, error) {
+	start := time.Now()
+	stmt, err := e.executor.Prepare(query)
+	logStatement(context.Background(), e.logger, query, nil, start, err)
+	return stmt, err
+}
+
+func (e *SQLExecutor) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	start := time.Now()
+	stmt, err := e.executor.PrepareContext(ctx, query)
+	logStatement(ctx, e.logger, query, nil, start, err)
+	return stmt, err
+}
@@ -0,0 +1,79 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/kozmod/oniontx-examples/internal/txid"
+)
+
+type (
+	transactor interface {
+		WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error)
+	}
+
+	// nestedKey marks nested calls of the particular Transactor,
+	// so a Transactor isn't treated as nested because of the transaction ID injected by another decorator.
+	nestedKey struct {
+		transactor *Transactor
+	}
+)
+
+// Transactor decorates a transactor and logs begin, commit and rollback of transactions.
+//
+// The highest level WithinTx call obtains (or generates) the transaction ID
+// and injects the logger with the ID into [context.Context] (look at [FromContext]).
+// Nested calls are not logged, since they do not begin or finish the transaction.
+type Transactor struct {
+	transactor transactor
+	logger     *slog.Logger
+}
+
+// NewTransactor returns new Transactor.
+func NewTransactor(transactor transactor, logger *slog.Logger) *Transactor {
+	return &Transactor{
+		transactor: transactor,
+		logger:     logger,
+	}
+}
+
+// WithinTx calls WithinTx of the decorated transactor and logs the transaction's lifecycle.
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if nested, _ := ctx.Value(nestedKey{transactor: t}).(bool); nested {
+		return t.transactor.WithinTx(ctx, fn)
+	}
+
+	id, ok := txid.Extract(ctx)
+	if !ok {
+		id = txid.New()
+		ctx = txid.Inject(ctx, id)
+	}
+
+	var (
+		logger   = FromContext(ctx, t.logger).With(slog.String(AttrTxID, id))
+		start    = time.Now()
+		fnFailed = false
+	)
+	ctx = WithLogger(ctx, logger)
+
+	logger.DebugContext(ctx, "tx begin")
+	defer func() {
+		elapsed := slog.Duration(AttrElapsed, time.Since(start))
+		switch {
+		case fnFailed:
+			logger.WarnContext(ctx, "tx rollback", elapsed, slog.Any(AttrError, err))
+		case err != nil:
+			logger.ErrorContext(ctx, "tx failed", elapsed, slog.Any(AttrError, err))
+		default:
+			logger.DebugContext(ctx, "tx commit", elapsed)
+		}
+	}()
+
+	return t.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		fnFailed = true
+		err := fn(context.WithValue(ctx, nestedKey{transactor: t}, true))
+		fnFailed = err != nil
+		return err
+	})
+}
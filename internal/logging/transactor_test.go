@@ -0,0 +1,142 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/txid"
+)
+
+type transactorFunc func(ctx context.Context, fn func(ctx context.Context) error) error
+
+func (f transactorFunc) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return f(ctx, fn)
+}
+
+func passThrough(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func Test_Transactor(t *testing.T) {
+	t.Run("commit", func(t *testing.T) {
+		var (
+			ctx         = context.Background()
+			logger, buf = NewLogger()
+			transactor  = NewTransactor(transactorFunc(passThrough), logger)
+		)
+
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			return transactor.WithinTx(ctx, func(ctx context.Context) error {
+				FromContext(ctx, nil).InfoContext(ctx, "repository")
+				return nil
+			})
+		})
+		assert.NoError(t, err)
+
+		records := Records(t, buf)
+		assert.Len(t, records, 3)
+
+		txID := records[0][AttrTxID]
+		assert.NotEmpty(t, txID)
+		for i, msg := range []string{"tx begin", "repository", "tx commit"} {
+			assert.Equal(t, msg, records[i][slog.MessageKey])
+			assert.Equal(t, txID, records[i][AttrTxID])
+		}
+		assert.Contains(t, records[2], AttrElapsed)
+	})
+	t.Run("rollback", func(t *testing.T) {
+		var (
+			ctx         = context.Background()
+			expErr      = fmt.Errorf("some_error")
+			logger, buf = NewLogger()
+			transactor  = NewTransactor(transactorFunc(passThrough), logger)
+		)
+
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			return expErr
+		})
+		assert.ErrorIs(t, err, expErr)
+
+		records := Records(t, buf)
+		assert.Len(t, records, 2)
+		assert.Equal(t, "tx rollback", records[1][slog.MessageKey])
+		assert.Equal(t, slog.LevelWarn.String(), records[1][slog.LevelKey])
+		assert.Equal(t, expErr.Error(), records[1][AttrError])
+		assert.Equal(t, records[0][AttrTxID], records[1][AttrTxID])
+	})
+	t.Run("commit_failed", func(t *testing.T) {
+		var (
+			ctx         = context.Background()
+			commitErr   = fmt.Errorf("commit_error")
+			logger, buf = NewLogger()
+			transactor  = NewTransactor(transactorFunc(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				if err := fn(ctx); err != nil {
+					return err
+				}
+				return commitErr
+			}), logger)
+		)
+
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, commitErr)
+
+		records := Records(t, buf)
+		assert.Len(t, records, 2)
+		assert.Equal(t, "tx failed", records[1][slog.MessageKey])
+		assert.Equal(t, slog.LevelError.String(), records[1][slog.LevelKey])
+	})
+	t.Run("tx_id_from_context", func(t *testing.T) {
+		var (
+			id          = txid.New()
+			ctx         = txid.Inject(context.Background(), id)
+			logger, buf = NewLogger()
+			transactor  = NewTransactor(transactorFunc(passThrough), logger)
+		)
+
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			return nil
+		})
+		assert.NoError(t, err)
+
+		records := Records(t, buf)
+		assert.Len(t, records, 2)
+		for i, msg := range []string{"tx begin", "tx commit"} {
+			assert.Equal(t, msg, records[i][slog.MessageKey])
+			assert.Equal(t, id, records[i][AttrTxID])
+		}
+	})
+	t.Run("different_transactors_logged", func(t *testing.T) {
+		var (
+			ctx                   = context.Background()
+			outerLogger, outerBuf = NewLogger()
+			innerLogger, innerBuf = NewLogger()
+			outer                 = NewTransactor(transactorFunc(passThrough), outerLogger)
+			inner                 = NewTransactor(transactorFunc(passThrough), innerLogger)
+		)
+
+		err := outer.WithinTx(ctx, func(ctx context.Context) error {
+			return inner.WithinTx(ctx, func(ctx context.Context) error {
+				return nil
+			})
+		})
+		assert.NoError(t, err)
+
+		var (
+			outerRecords = Records(t, outerBuf)
+			innerRecords = Records(t, innerBuf)
+		)
+		// the inner transactor logs with the logger from the context
+		assert.Len(t, outerRecords, 4)
+		assert.Empty(t, innerRecords)
+		for i, msg := range []string{"tx begin", "tx begin", "tx commit", "tx commit"} {
+			assert.Equal(t, msg, outerRecords[i][slog.MessageKey])
+			assert.Equal(t, outerRecords[0][AttrTxID], outerRecords[i][AttrTxID])
+		}
+	})
+}
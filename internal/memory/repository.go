@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+)
+
+type (
+	repoTransactor interface {
+		TryGetTx(ctx context.Context) (*Tx, bool)
+		TxBeginner() *Store
+	}
+)
+
+// TextRepository is the in-memory fake of the drivers' text repositories.
+//
+// Writes within a transaction are buffered by [Tx] until the commit,
+// writes without a transaction go to [Store] immediately.
+type TextRepository struct {
+	transactor repoTransactor
+}
+
+// NewTextRepository returns new TextRepository.
+func NewTextRepository(transactor repoTransactor) *TextRepository {
+	return &TextRepository{
+		transactor: transactor,
+	}
+}
+
+// Insert inserts the text record.
+func (r *TextRepository) Insert(ctx context.Context, val string) error {
+	tx, ok := r.transactor.TryGetTx(ctx)
+	if !ok {
+		r.transactor.TxBeginner().insert(val)
+		return nil
+	}
+	if err := tx.insert(val); err != nil {
+		return fmt.Errorf("memory repository - insert: %w", err)
+	}
+	return nil
+}
+
+// Texts returns the text records visible within [context.Context]:
+// the committed records and the writes of the transaction from [context.Context].
+func (r *TextRepository) Texts(ctx context.Context) []string {
+	if tx, ok := r.transactor.TryGetTx(ctx); ok {
+		return tx.Texts()
+	}
+	return r.transactor.TxBeginner().Texts()
+}
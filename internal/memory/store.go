@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/kozmod/oniontx"
+)
+
+// ErrTxDone is returned by operations on the committed or the rolled back transaction.
+var ErrTxDone = errors.New("memory - transaction has already been committed or rolled back")
+
+// TxOptions is the options of the in-memory transaction.
+type TxOptions struct {
+	// ReadOnly forbids writes within the transaction.
+	ReadOnly bool
+}
+
+// WithReadOnly returns [oniontx.Option] which forbids writes within the transaction.
+func WithReadOnly() oniontx.Option[*TxOptions] {
+	return txOption(func(opts *TxOptions) {
+		opts.ReadOnly = true
+	})
+}
+
+type txOption func(opts *TxOptions)
+
+func (o txOption) Apply(opts *TxOptions) {
+	o(opts)
+}
+
+// Store contains the committed text records and implements [oniontx.TxBeginner].
+type Store struct {
+	mx    sync.Mutex
+	texts []string
+}
+
+// NewStore returns new Store.
+func NewStore() *Store {
+	return &Store{}
+}
+
+// BeginTx starts a transaction which buffers writes until the commit.
+func (s *Store) BeginTx(_ context.Context, opts ...oniontx.Option[*TxOptions]) (*Tx, error) {
+	var txOptions TxOptions
+	for _, opt := range opts {
+		opt.Apply(&txOptions)
+	}
+	return &Tx{
+		store:   s,
+		options: txOptions,
+	}, nil
+}
+
+// Texts returns the committed text records.
+func (s *Store) Texts() []string {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return slices.Clone(s.texts)
+}
+
+func (s *Store) insert(texts ...string) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.texts = append(s.texts, texts...)
+}
+
+// Tx is the in-memory transaction and implements [oniontx.Tx].
+type Tx struct {
+	mx      sync.Mutex
+	store   *Store
+	options TxOptions
+	texts   []string
+	done    bool
+}
+
+// Commit moves the buffered writes to the Store.
+func (t *Tx) Commit(_ context.Context) error {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+	if t.done {
+		return ErrTxDone
+	}
+	t.done = true
+	t.store.insert(t.texts...)
+	t.texts = nil
+	return nil
+}
+
+// Rollback discards the buffered writes.
+func (t *Tx) Rollback(_ context.Context) error {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+	if t.done {
+		return ErrTxDone
+	}
+	t.done = true
+	t.texts = nil
+	return nil
+}
+
+// Texts returns the committed text records and the transaction's buffered writes.
+func (t *Tx) Texts() []string {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+	return append(t.store.Texts(), t.texts...)
+}
+
+func (t *Tx) insert(text string) error {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+	switch {
+	case t.done:
+		return ErrTxDone
+	case t.options.ReadOnly:
+		return fmt.Errorf("memory - insert within read only transaction: %w", errors.ErrUnsupported)
+	}
+	t.texts = append(t.texts, text)
+	return nil
+}
@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/kozmod/oniontx"
+)
+
+// Transactor manage a transaction for single [Store] instance.
+//
+// Transactor is based on [oniontx.Transactor] like the drivers' transactors, so nested WithinTx calls,
+// errors and panics are handled the same way.
+type Transactor struct {
+	*oniontx.Transactor[*Store, *Tx, *TxOptions]
+}
+
+// NewTransactor returns new Transactor.
+func NewTransactor(store *Store) *Transactor {
+	var (
+		operator   = oniontx.NewContextOperator[*Store, *Tx](store)
+		transactor = oniontx.NewTransactor[*Store, *Tx, *TxOptions](store, operator)
+	)
+	return &Transactor{
+		Transactor: transactor,
+	}
+}
+
+// TryGetTx returns [Tx] and "true" from [context.Context] or return `false`.
+func (t *Transactor) TryGetTx(ctx context.Context) (*Tx, bool) {
+	tx, ok := t.Transactor.TryGetTx(ctx)
+	if !ok || tx == nil {
+		return nil, false
+	}
+	return tx, true
+}
+
+// TxBeginner returns pointer of [Store].
+func (t *Transactor) TxBeginner() *Store {
+	return t.Transactor.TxBeginner()
+}
@@ -0,0 +1,136 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kozmod/oniontx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+)
+
+const (
+	textRecord = "text_A"
+)
+
+func Test_Transactor(t *testing.T) {
+	t.Run("commit", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			store      = NewStore()
+			transactor = NewTransactor(store)
+			repository = NewTextRepository(transactor)
+		)
+
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			err := repository.Insert(ctx, textRecord)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{textRecord}, repository.Texts(ctx))
+			assert.Empty(t, store.Texts())
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{textRecord}, store.Texts())
+	})
+	t.Run("rollback", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			store      = NewStore()
+			transactor = NewTransactor(store)
+			repository = NewTextRepository(transactor)
+		)
+
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			err := repository.Insert(ctx, textRecord)
+			assert.NoError(t, err)
+			return entity.ErrExpected
+		})
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.ErrorIs(t, err, oniontx.ErrRollbackSuccess)
+		assert.Empty(t, store.Texts())
+	})
+	t.Run("panic_and_rollback", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			store      = NewStore()
+			transactor = NewTransactor(store)
+			repository = NewTextRepository(transactor)
+		)
+
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			err := repository.Insert(ctx, textRecord)
+			assert.NoError(t, err)
+			panic("some panic")
+		})
+		assert.ErrorIs(t, err, oniontx.ErrRollbackSuccess)
+		assert.Empty(t, store.Texts())
+	})
+	t.Run("nested_rollback", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			store      = NewStore()
+			transactor = NewTransactor(store)
+			repository = NewTextRepository(transactor)
+		)
+
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+				return repository.Insert(ctx, textRecord)
+			})
+			assert.NoError(t, err)
+			assert.Empty(t, store.Texts())
+
+			return transactor.WithinTx(ctx, func(ctx context.Context) error {
+				return entity.ErrExpected
+			})
+		})
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.Empty(t, store.Texts())
+	})
+	t.Run("without_tx", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			store      = NewStore()
+			repository = NewTextRepository(NewTransactor(store))
+		)
+
+		err := repository.Insert(ctx, textRecord)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{textRecord}, store.Texts())
+	})
+	t.Run("read_only", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			store      = NewStore()
+			transactor = NewTransactor(store)
+			repository = NewTextRepository(transactor)
+		)
+
+		err := transactor.WithinTxWithOpts(ctx, func(ctx context.Context) error {
+			return repository.Insert(ctx, textRecord)
+		}, WithReadOnly())
+		assert.ErrorIs(t, err, errors.ErrUnsupported)
+		assert.Empty(t, store.Texts())
+	})
+	t.Run("tx_done", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			store      = NewStore()
+			transactor = NewTransactor(store)
+			repository = NewTextRepository(transactor)
+			txCtx      context.Context
+		)
+
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			txCtx = ctx
+			return nil
+		})
+		assert.NoError(t, err)
+
+		err = repository.Insert(txCtx, textRecord)
+		assert.ErrorIs(t, err, ErrTxDone)
+		assert.Empty(t, store.Texts())
+	})
+}
@@ -0,0 +1,157 @@
+package metrics
+
+//go:generate mockery --dir=. --name=transactor --outpkg=metrics --output=.  --filename=mock_transactor_test.go --structname=transactorMock
+//go:generate git add .
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	DriverStdlib = "stdlib"
+	DriverSqlx   = "sqlx"
+	DriverPgx    = "pgx"
+	DriverGorm   = "gorm"
+
+	unknownUseCase = "unknown"
+
+	namespace = "oniontx"
+	subsystem = "tx"
+
+	labelDriver  = "driver"
+	labelUseCase = "usecase"
+)
+
+type (
+	transactor interface {
+		WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error)
+	}
+)
+
+type (
+	useCaseKey struct{}
+
+	// nestedKey marks nested calls of the particular Transactor,
+	// so a Transactor called within another one (e.g. of a different driver) still observes its transactions.
+	nestedKey struct {
+		transactor *Transactor
+	}
+)
+
+// WithUseCase returns new [context.Context] which carries the use case name for the metrics labels.
+func WithUseCase(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, useCaseKey{}, name)
+}
+
+// UseCaseFromContext returns the use case name from [context.Context] or `unknown`.
+func UseCaseFromContext(ctx context.Context) string {
+	if name, ok := ctx.Value(useCaseKey{}).(string); ok && name != "" {
+		return name
+	}
+	return unknownUseCase
+}
+
+// Metrics contains transactions' collectors.
+type Metrics struct {
+	commits   *prometheus.CounterVec
+	rollbacks *prometheus.CounterVec
+	errors    *prometheus.CounterVec
+	duration  *prometheus.HistogramVec
+}
+
+// NewMetrics returns new Metrics and registers the collectors with the registerer.
+func NewMetrics(registerer prometheus.Registerer) (*Metrics, error) {
+	labels := []string{labelDriver, labelUseCase}
+	m := Metrics{
+		commits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "commits_total",
+			Help:      "Number of committed transactions.",
+		}, labels),
+		rollbacks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "rollbacks_total",
+			Help:      "Number of transactions rolled back because of a use case error or panic.",
+		}, labels),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "errors_total",
+			Help:      "Number of transactions failed to begin or commit.",
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "duration_seconds",
+			Help:      "Duration of transactions.",
+			Buckets:   prometheus.DefBuckets,
+		}, labels),
+	}
+
+	for _, collector := range []prometheus.Collector{m.commits, m.rollbacks, m.errors, m.duration} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, fmt.Errorf("metrics - register: %w", err)
+		}
+	}
+	return &m, nil
+}
+
+// Transactor decorates a transactor and observes transactions' outcomes and durations.
+//
+// Only the highest level WithinTx call is observed, since nested calls do not commit or roll back the transaction.
+// Each observed transaction is counted once: as a commit, as a rollback (the function returns an error or panics)
+// or as an error (the transaction fails to begin or to commit).
+type Transactor struct {
+	transactor transactor
+	metrics    *Metrics
+	driver     string
+}
+
+// NewTransactor returns new Transactor.
+func NewTransactor(transactor transactor, metrics *Metrics, driver string) *Transactor {
+	return &Transactor{
+		transactor: transactor,
+		metrics:    metrics,
+		driver:     driver,
+	}
+}
+
+// WithinTx calls WithinTx of the decorated transactor and observes the result.
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if nested, _ := ctx.Value(nestedKey{transactor: t}).(bool); nested {
+		return t.transactor.WithinTx(ctx, fn)
+	}
+
+	var (
+		start    = time.Now()
+		fnFailed = false
+		labels   = prometheus.Labels{
+			labelDriver:  t.driver,
+			labelUseCase: UseCaseFromContext(ctx),
+		}
+	)
+	defer func() {
+		t.metrics.duration.With(labels).Observe(time.Since(start).Seconds())
+		switch {
+		case fnFailed:
+			t.metrics.rollbacks.With(labels).Inc()
+		case err != nil:
+			t.metrics.errors.With(labels).Inc()
+		default:
+			t.metrics.commits.With(labels).Inc()
+		}
+	}()
+
+	return t.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		fnFailed = true
+		err := fn(context.WithValue(ctx, nestedKey{transactor: t}, true))
+		fnFailed = err != nil
+		return err
+	})
+}
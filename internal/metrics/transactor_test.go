@@ -0,0 +1,165 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	useCaseName = "create_text_records"
+
+	transactorMethodWithinTx = "WithinTx"
+)
+
+func runFn(ctx context.Context) func(args mock.Arguments) {
+	return func(args mock.Arguments) {
+		fn, ok := args.Get(1).(func(context.Context) error)
+		if ok {
+			_ = fn(ctx)
+		}
+	}
+}
+
+func Test_Transactor(t *testing.T) {
+	t.Run("commit", func(t *testing.T) {
+		var (
+			ctx            = WithUseCase(context.Background(), useCaseName)
+			transactorMock = newTransactorMock(t)
+			metrics, err   = NewMetrics(prometheus.NewRegistry())
+		)
+		assert.NoError(t, err)
+
+		transactorMock.On(transactorMethodWithinTx, ctx, mock.Anything).
+			Run(runFn(ctx)).
+			Return(nil)
+
+		transactor := NewTransactor(transactorMock, metrics, DriverPgx)
+		err = transactor.WithinTx(ctx, func(ctx context.Context) error {
+			return nil
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, float64(1), testutil.ToFloat64(metrics.commits.WithLabelValues(DriverPgx, useCaseName)))
+		assert.Equal(t, float64(0), testutil.ToFloat64(metrics.rollbacks.WithLabelValues(DriverPgx, useCaseName)))
+		assert.Equal(t, float64(0), testutil.ToFloat64(metrics.errors.WithLabelValues(DriverPgx, useCaseName)))
+		assert.Equal(t, 1, testutil.CollectAndCount(metrics.duration, "oniontx_tx_duration_seconds"))
+	})
+	t.Run("rollback", func(t *testing.T) {
+		var (
+			ctx            = WithUseCase(context.Background(), useCaseName)
+			expErr         = fmt.Errorf("some_error")
+			transactorMock = newTransactorMock(t)
+			metrics, err   = NewMetrics(prometheus.NewRegistry())
+		)
+		assert.NoError(t, err)
+
+		transactorMock.On(transactorMethodWithinTx, ctx, mock.Anything).
+			Run(runFn(ctx)).
+			Return(expErr)
+
+		transactor := NewTransactor(transactorMock, metrics, DriverStdlib)
+		err = transactor.WithinTx(ctx, func(ctx context.Context) error {
+			return expErr
+		})
+		assert.ErrorIs(t, err, expErr)
+
+		assert.Equal(t, float64(0), testutil.ToFloat64(metrics.commits.WithLabelValues(DriverStdlib, useCaseName)))
+		assert.Equal(t, float64(1), testutil.ToFloat64(metrics.rollbacks.WithLabelValues(DriverStdlib, useCaseName)))
+		assert.Equal(t, float64(0), testutil.ToFloat64(metrics.errors.WithLabelValues(DriverStdlib, useCaseName)))
+		assert.Equal(t, 1, testutil.CollectAndCount(metrics.duration, "oniontx_tx_duration_seconds"))
+	})
+	t.Run("commit_error", func(t *testing.T) {
+		var (
+			ctx            = WithUseCase(context.Background(), useCaseName)
+			commitErr      = fmt.Errorf("commit_error")
+			transactorMock = newTransactorMock(t)
+			metrics, err   = NewMetrics(prometheus.NewRegistry())
+		)
+		assert.NoError(t, err)
+
+		transactorMock.On(transactorMethodWithinTx, ctx, mock.Anything).
+			Run(runFn(ctx)).
+			Return(commitErr)
+
+		transactor := NewTransactor(transactorMock, metrics, DriverSqlx)
+		err = transactor.WithinTx(ctx, func(ctx context.Context) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, commitErr)
+
+		assert.Equal(t, float64(0), testutil.ToFloat64(metrics.commits.WithLabelValues(DriverSqlx, useCaseName)))
+		assert.Equal(t, float64(0), testutil.ToFloat64(metrics.rollbacks.WithLabelValues(DriverSqlx, useCaseName)))
+		assert.Equal(t, float64(1), testutil.ToFloat64(metrics.errors.WithLabelValues(DriverSqlx, useCaseName)))
+	})
+	t.Run("nested_observed_once", func(t *testing.T) {
+		var (
+			ctx            = context.Background()
+			transactorMock = newTransactorMock(t)
+			metrics, err   = NewMetrics(prometheus.NewRegistry())
+		)
+		assert.NoError(t, err)
+
+		transactorMock.On(transactorMethodWithinTx, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				fn, ok := args.Get(1).(func(context.Context) error)
+				if ok {
+					_ = fn(args.Get(0).(context.Context))
+				}
+			}).
+			Return(nil).
+			Twice()
+
+		transactor := NewTransactor(transactorMock, metrics, DriverGorm)
+		err = transactor.WithinTx(ctx, func(ctx context.Context) error {
+			return transactor.WithinTx(ctx, func(ctx context.Context) error {
+				return nil
+			})
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, float64(1), testutil.ToFloat64(metrics.commits.WithLabelValues(DriverGorm, unknownUseCase)))
+		assert.Equal(t, 1, testutil.CollectAndCount(metrics.duration, "oniontx_tx_duration_seconds"))
+	})
+	t.Run("different_transactors_observed", func(t *testing.T) {
+		var (
+			ctx          = context.Background()
+			stdlibMock   = newTransactorMock(t)
+			gormMock     = newTransactorMock(t)
+			metrics, err = NewMetrics(prometheus.NewRegistry())
+		)
+		assert.NoError(t, err)
+
+		for _, transactorMock := range []*transactorMock{stdlibMock, gormMock} {
+			transactorMock.On(transactorMethodWithinTx, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					fn, ok := args.Get(1).(func(context.Context) error)
+					if ok {
+						_ = fn(args.Get(0).(context.Context))
+					}
+				}).
+				Return(nil).
+				Once()
+		}
+
+		var (
+			stdlibTransactor = NewTransactor(stdlibMock, metrics, DriverStdlib)
+			gormTransactor   = NewTransactor(gormMock, metrics, DriverGorm)
+		)
+		err = stdlibTransactor.WithinTx(ctx, func(ctx context.Context) error {
+			return gormTransactor.WithinTx(ctx, func(ctx context.Context) error {
+				return nil
+			})
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, float64(1), testutil.ToFloat64(metrics.commits.WithLabelValues(DriverStdlib, unknownUseCase)))
+		assert.Equal(t, float64(1), testutil.ToFloat64(metrics.commits.WithLabelValues(DriverGorm, unknownUseCase)))
+		assert.Equal(t, 2, testutil.CollectAndCount(metrics.duration, "oniontx_tx_duration_seconds"))
+	})
+}
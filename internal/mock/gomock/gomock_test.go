@@ -0,0 +1,108 @@
+package gomock
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	textValue = "some_text"
+)
+
+// txFn matches the function passed to [transactor.WithinTx] by its type only,
+// the function is called by the WithinTx's action ([gomock.Call.DoAndReturn]) like the real transactor calls it.
+func txFn() gomock.Matcher {
+	return gomock.AssignableToTypeOf(func(ctx context.Context) error { return nil })
+}
+
+func Test_gomock(t *testing.T) {
+	t.Run("assert_success", func(t *testing.T) {
+		var (
+			ctx  = context.Background()
+			ctrl = gomock.NewController(t)
+
+			transactorMock  = NewMocktransactor(ctrl)
+			repositoryMockA = NewMockrepository(ctrl)
+			repositoryMockB = NewMockrepository(ctrl)
+		)
+
+		gomock.InOrder(
+			repositoryMockA.EXPECT().Insert(ctx, textValue).Return(nil),
+			repositoryMockB.EXPECT().Insert(ctx, textValue).Return(nil),
+		)
+		transactorMock.EXPECT().WithinTx(ctx, txFn()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+		useCase := UseCase{
+			transactor: transactorMock,
+			textRepoA:  repositoryMockA,
+			textRepoB:  repositoryMockB,
+		}
+
+		err := useCase.CreateTextRecords(ctx, textValue)
+		assert.NoError(t, err)
+	})
+	t.Run("assert_error", func(t *testing.T) {
+		var (
+			ctx           = context.Background()
+			ctrl          = gomock.NewController(t)
+			expError      = fmt.Errorf("some_error")
+			transactorErr = fmt.Errorf("transactor_error")
+
+			transactorMock  = NewMocktransactor(ctrl)
+			repositoryMockA = NewMockrepository(ctrl)
+			repositoryMockB = NewMockrepository(ctrl)
+		)
+
+		gomock.InOrder(
+			repositoryMockA.EXPECT().Insert(ctx, textValue).Return(nil),
+			repositoryMockB.EXPECT().Insert(ctx, textValue).Return(expError),
+		)
+		transactorMock.EXPECT().WithinTx(ctx, txFn()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			err := fn(ctx)
+			assert.ErrorIs(t, err, expError)
+			return transactorErr
+		})
+
+		useCase := UseCase{
+			transactor: transactorMock,
+			textRepoA:  repositoryMockA,
+			textRepoB:  repositoryMockB,
+		}
+
+		err := useCase.CreateTextRecords(ctx, textValue)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, transactorErr)
+	})
+	t.Run("assert_first_error_stops", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			ctrl     = gomock.NewController(t)
+			expError = fmt.Errorf("some_error")
+
+			transactorMock  = NewMocktransactor(ctrl)
+			repositoryMockA = NewMockrepository(ctrl)
+			repositoryMockB = NewMockrepository(ctrl)
+		)
+
+		// repositoryMockB has no expectations: any call fails the test.
+		repositoryMockA.EXPECT().Insert(ctx, textValue).Return(expError)
+		transactorMock.EXPECT().WithinTx(ctx, txFn()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+		useCase := UseCase{
+			transactor: transactorMock,
+			textRepoA:  repositoryMockA,
+			textRepoB:  repositoryMockB,
+		}
+
+		err := useCase.CreateTextRecords(ctx, textValue)
+		assert.ErrorIs(t, err, expError)
+	})
+}
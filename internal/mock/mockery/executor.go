@@ -0,0 +1,64 @@
+package mockery
+
+//go:generate mockery --dir=. --name=stdlibRepoTransactor --outpkg=mockery --output=.  --filename=mock_stdlib_repo_transactor_test.go --structname=stdlibRepoTransactorMock
+//go:generate mockery --dir=. --name=stdlibExecutor --outpkg=mockery --output=.  --filename=mock_stdlib_executor_test.go --structname=stdlibExecutorMock
+//go:generate mockery --dir=. --name=sqlxRepoTransactor --outpkg=mockery --output=.  --filename=mock_sqlx_repo_transactor_test.go --structname=sqlxRepoTransactorMock
+//go:generate mockery --dir=. --name=sqlxExecutor --outpkg=mockery --output=.  --filename=mock_sqlx_executor_test.go --structname=sqlxExecutorMock
+//go:generate mockery --dir=. --name=pgxRepoTransactor --outpkg=mockery --output=.  --filename=mock_pgx_repo_transactor_test.go --structname=pgxRepoTransactorMock
+//go:generate mockery --dir=. --name=pgxExecutor --outpkg=mockery --output=.  --filename=mock_pgx_executor_test.go --structname=pgxExecutorMock
+//go:generate mockery --dir=. --name=gormRepoTransactor --outpkg=mockery --output=.  --filename=mock_gorm_repo_transactor_test.go --structname=gormRepoTransactorMock
+//go:generate mockery --dir=. --name=gormConnPool --outpkg=mockery --output=.  --filename=mock_gorm_conn_pool_test.go --structname=gormConnPoolMock
+//go:generate git add .
+
+import (
+	"context"
+
+	ogorm "github.com/kozmod/oniontx/gorm"
+	opgx "github.com/kozmod/oniontx/pgx"
+	osqlx "github.com/kozmod/oniontx/sqlx"
+	ostdlib "github.com/kozmod/oniontx/stdlib"
+	"gorm.io/gorm"
+)
+
+// Interfaces required by the drivers' repositories (`repoTransactor`) and the executors returned by them.
+type (
+	stdlibRepoTransactor interface {
+		GetExecutor(ctx context.Context) ostdlib.Executor
+	}
+
+	stdlibExecutor interface {
+		ostdlib.Executor
+	}
+
+	sqlxRepoTransactor interface {
+		GetExecutor(ctx context.Context) osqlx.Executor
+	}
+
+	sqlxExecutor interface {
+		osqlx.Executor
+	}
+
+	pgxRepoTransactor interface {
+		GetExecutor(ctx context.Context) opgx.Executor
+	}
+
+	pgxExecutor interface {
+		opgx.Executor
+	}
+
+	gormRepoTransactor interface {
+		GetExecutor(ctx context.Context) *gorm.DB
+	}
+
+	// gormConnPool is the connection of [gorm.DB] and the executor of [ogorm.Transactor.GetExecutor].
+	gormConnPool interface {
+		gorm.ConnPool
+	}
+)
+
+var (
+	_ stdlibRepoTransactor = (*ostdlib.Transactor)(nil)
+	_ sqlxRepoTransactor   = (*osqlx.Transactor)(nil)
+	_ pgxRepoTransactor    = (*opgx.Transactor)(nil)
+	_ gormRepoTransactor   = (*ogorm.Transactor)(nil)
+)
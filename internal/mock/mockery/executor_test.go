@@ -0,0 +1,169 @@
+package mockery
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+	gormexample "github.com/kozmod/oniontx-examples/internal/gorm"
+	pgxexample "github.com/kozmod/oniontx-examples/internal/pgx"
+	sqlxexample "github.com/kozmod/oniontx-examples/internal/sqlx"
+	stdlibexample "github.com/kozmod/oniontx-examples/internal/stdlib"
+)
+
+const (
+	insertQuery     = `INSERT INTO text (val) VALUES ($1)`
+	gormInsertQuery = `INSERT INTO "text" ("val") VALUES ($1)`
+
+	repoTransactorMethodGetExecutor = "GetExecutor"
+	executorMethodExecContext       = "ExecContext"
+	executorMethodExec              = "Exec"
+)
+
+func Test_executors(t *testing.T) {
+	t.Run("stdlib", func(t *testing.T) {
+		t.Run("assert_insert", func(t *testing.T) {
+			ctx := context.Background()
+
+			executorMock := newStdlibExecutorMock(t)
+			executorMock.On(executorMethodExecContext, ctx, insertQuery, textValue).Return(driver.RowsAffected(1), nil)
+
+			repoTransactorMock := newStdlibRepoTransactorMock(t)
+			repoTransactorMock.On(repoTransactorMethodGetExecutor, ctx).Return(executorMock)
+
+			err := stdlibexample.NewTextRepository(repoTransactorMock).Insert(ctx, textValue)
+			assert.NoError(t, err)
+		})
+		t.Run("assert_error", func(t *testing.T) {
+			var (
+				ctx      = context.Background()
+				expError = &pgconn.PgError{Code: "23505"}
+			)
+
+			executorMock := newStdlibExecutorMock(t)
+			executorMock.On(executorMethodExecContext, ctx, insertQuery, textValue).Return(nil, expError)
+
+			repoTransactorMock := newStdlibRepoTransactorMock(t)
+			repoTransactorMock.On(repoTransactorMethodGetExecutor, ctx).Return(executorMock)
+
+			err := stdlibexample.NewTextRepository(repoTransactorMock).Insert(ctx, textValue)
+			assert.ErrorIs(t, err, expError)
+			assert.ErrorIs(t, err, entity.ErrDuplicate)
+		})
+	})
+	t.Run("sqlx", func(t *testing.T) {
+		t.Run("assert_insert", func(t *testing.T) {
+			ctx := context.Background()
+
+			executorMock := newSqlxExecutorMock(t)
+			executorMock.On(executorMethodExecContext, ctx, insertQuery, textValue).Return(driver.RowsAffected(1), nil)
+
+			repoTransactorMock := newSqlxRepoTransactorMock(t)
+			repoTransactorMock.On(repoTransactorMethodGetExecutor, ctx).Return(executorMock)
+
+			err := sqlxexample.NewTextRepository(repoTransactorMock).Insert(ctx, textValue)
+			assert.NoError(t, err)
+		})
+		t.Run("assert_error", func(t *testing.T) {
+			var (
+				ctx      = context.Background()
+				expError = fmt.Errorf("some_error")
+			)
+
+			executorMock := newSqlxExecutorMock(t)
+			executorMock.On(executorMethodExecContext, ctx, insertQuery, textValue).Return(nil, expError)
+
+			repoTransactorMock := newSqlxRepoTransactorMock(t)
+			repoTransactorMock.On(repoTransactorMethodGetExecutor, ctx).Return(executorMock)
+
+			err := sqlxexample.NewTextRepository(repoTransactorMock).Insert(ctx, textValue)
+			assert.ErrorIs(t, err, expError)
+		})
+	})
+	t.Run("pgx", func(t *testing.T) {
+		t.Run("assert_insert", func(t *testing.T) {
+			ctx := context.Background()
+
+			executorMock := newPgxExecutorMock(t)
+			executorMock.On(executorMethodExec, ctx, insertQuery, textValue).Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+
+			repoTransactorMock := newPgxRepoTransactorMock(t)
+			repoTransactorMock.On(repoTransactorMethodGetExecutor, ctx).Return(executorMock)
+
+			err := pgxexample.NewTextRepository(repoTransactorMock).Insert(ctx, textValue)
+			assert.NoError(t, err)
+		})
+		t.Run("assert_error", func(t *testing.T) {
+			var (
+				ctx      = context.Background()
+				expError = &pgconn.PgError{Code: "23503"}
+			)
+
+			executorMock := newPgxExecutorMock(t)
+			executorMock.On(executorMethodExec, ctx, insertQuery, textValue).Return(pgconn.CommandTag{}, expError)
+
+			repoTransactorMock := newPgxRepoTransactorMock(t)
+			repoTransactorMock.On(repoTransactorMethodGetExecutor, ctx).Return(executorMock)
+
+			err := pgxexample.NewTextRepository(repoTransactorMock).Insert(ctx, textValue)
+			assert.ErrorIs(t, err, expError)
+			assert.ErrorIs(t, err, entity.ErrForeignKey)
+		})
+	})
+	t.Run("gorm", func(t *testing.T) {
+		newGormDB := func(t *testing.T, connPool gorm.ConnPool) *gorm.DB {
+			db, err := gorm.Open(postgres.New(postgres.Config{Conn: connPool}), &gorm.Config{
+				SkipDefaultTransaction: true,
+			})
+			assert.NoError(t, err)
+			return db
+		}
+
+		t.Run("assert_raw_insert", func(t *testing.T) {
+			ctx := context.Background()
+
+			connPoolMock := newGormConnPoolMock(t)
+			connPoolMock.On(executorMethodExecContext, ctx, insertQuery, textValue).Return(driver.RowsAffected(1), nil)
+
+			repoTransactorMock := newGormRepoTransactorMock(t)
+			repoTransactorMock.On(repoTransactorMethodGetExecutor, ctx).Return(newGormDB(t, connPoolMock))
+
+			err := gormexample.NewTextRepository(repoTransactorMock).RawInsert(ctx, textValue)
+			assert.NoError(t, err)
+		})
+		t.Run("assert_insert", func(t *testing.T) {
+			ctx := context.Background()
+
+			connPoolMock := newGormConnPoolMock(t)
+			connPoolMock.On(executorMethodExecContext, ctx, gormInsertQuery, textValue).Return(driver.RowsAffected(1), nil)
+
+			repoTransactorMock := newGormRepoTransactorMock(t)
+			repoTransactorMock.On(repoTransactorMethodGetExecutor, ctx).Return(newGormDB(t, connPoolMock))
+
+			err := gormexample.NewTextRepository(repoTransactorMock).Insert(ctx, gormexample.Text{Val: textValue})
+			assert.NoError(t, err)
+		})
+		t.Run("assert_error", func(t *testing.T) {
+			var (
+				ctx      = context.Background()
+				expError = fmt.Errorf("some_error")
+			)
+
+			connPoolMock := newGormConnPoolMock(t)
+			connPoolMock.On(executorMethodExecContext, ctx, insertQuery, textValue).Return(nil, expError)
+
+			repoTransactorMock := newGormRepoTransactorMock(t)
+			repoTransactorMock.On(repoTransactorMethodGetExecutor, ctx).Return(newGormDB(t, connPoolMock))
+
+			err := gormexample.NewTextRepository(repoTransactorMock).RawInsert(ctx, textValue)
+			assert.ErrorIs(t, err, expError)
+		})
+	})
+}
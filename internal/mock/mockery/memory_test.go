@@ -0,0 +1,36 @@
+package mockery
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/kozmod/oniontx-examples/internal/memory"
+)
+
+func Test_memory(t *testing.T) {
+	t.Run("assert_state_after_rollback", func(t *testing.T) {
+		var (
+			expError   = fmt.Errorf("some_error")
+			store      = memory.NewStore()
+			transactor = memory.NewTransactor(store)
+		)
+
+		repositoryMockB := new(repositoryMock)
+		repositoryMockB.On(repositoryMethodInsert, mock.Anything, textValue).Return(expError)
+
+		useCase := UseCase{
+			transactor: transactor,
+			textRepoA:  memory.NewTextRepository(transactor),
+			textRepoB:  repositoryMockB,
+		}
+
+		err := useCase.CreateTextRecords(context.Background(), textValue)
+		assert.ErrorIs(t, err, expError)
+		assert.Empty(t, store.Texts())
+		repositoryMockB.AssertExpectations(t)
+	})
+}
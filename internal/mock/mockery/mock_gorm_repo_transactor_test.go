@@ -0,0 +1,50 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mockery
+
+import (
+	context "context"
+
+	gorm "gorm.io/gorm"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// gormRepoTransactorMock is an autogenerated mock type for the gormRepoTransactor type
+type gormRepoTransactorMock struct {
+	mock.Mock
+}
+
+// GetExecutor provides a mock function with given fields: ctx
+func (_m *gormRepoTransactorMock) GetExecutor(ctx context.Context) *gorm.DB {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExecutor")
+	}
+
+	var r0 *gorm.DB
+	if rf, ok := ret.Get(0).(func(context.Context) *gorm.DB); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gorm.DB)
+		}
+	}
+
+	return r0
+}
+
+// newGormRepoTransactorMock creates a new instance of gormRepoTransactorMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newGormRepoTransactorMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *gormRepoTransactorMock {
+	mock := &gormRepoTransactorMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
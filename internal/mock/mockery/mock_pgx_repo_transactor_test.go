@@ -0,0 +1,50 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mockery
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	pgx "github.com/kozmod/oniontx/pgx"
+)
+
+// pgxRepoTransactorMock is an autogenerated mock type for the pgxRepoTransactor type
+type pgxRepoTransactorMock struct {
+	mock.Mock
+}
+
+// GetExecutor provides a mock function with given fields: ctx
+func (_m *pgxRepoTransactorMock) GetExecutor(ctx context.Context) pgx.Executor {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExecutor")
+	}
+
+	var r0 pgx.Executor
+	if rf, ok := ret.Get(0).(func(context.Context) pgx.Executor); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(pgx.Executor)
+		}
+	}
+
+	return r0
+}
+
+// newPgxRepoTransactorMock creates a new instance of pgxRepoTransactorMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newPgxRepoTransactorMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *pgxRepoTransactorMock {
+	mock := &pgxRepoTransactorMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
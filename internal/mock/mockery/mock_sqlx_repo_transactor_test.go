@@ -0,0 +1,50 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mockery
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlx "github.com/kozmod/oniontx/sqlx"
+)
+
+// sqlxRepoTransactorMock is an autogenerated mock type for the sqlxRepoTransactor type
+type sqlxRepoTransactorMock struct {
+	mock.Mock
+}
+
+// GetExecutor provides a mock function with given fields: ctx
+func (_m *sqlxRepoTransactorMock) GetExecutor(ctx context.Context) sqlx.Executor {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExecutor")
+	}
+
+	var r0 sqlx.Executor
+	if rf, ok := ret.Get(0).(func(context.Context) sqlx.Executor); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlx.Executor)
+		}
+	}
+
+	return r0
+}
+
+// newSqlxRepoTransactorMock creates a new instance of sqlxRepoTransactorMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newSqlxRepoTransactorMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *sqlxRepoTransactorMock {
+	mock := &sqlxRepoTransactorMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
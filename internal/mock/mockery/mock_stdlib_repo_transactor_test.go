@@ -0,0 +1,50 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mockery
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	stdlib "github.com/kozmod/oniontx/stdlib"
+)
+
+// stdlibRepoTransactorMock is an autogenerated mock type for the stdlibRepoTransactor type
+type stdlibRepoTransactorMock struct {
+	mock.Mock
+}
+
+// GetExecutor provides a mock function with given fields: ctx
+func (_m *stdlibRepoTransactorMock) GetExecutor(ctx context.Context) stdlib.Executor {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExecutor")
+	}
+
+	var r0 stdlib.Executor
+	if rf, ok := ret.Get(0).(func(context.Context) stdlib.Executor); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(stdlib.Executor)
+		}
+	}
+
+	return r0
+}
+
+// newStdlibRepoTransactorMock creates a new instance of stdlibRepoTransactorMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newStdlibRepoTransactorMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *stdlibRepoTransactorMock {
+	mock := &stdlibRepoTransactorMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,9 +3,12 @@ package mockery
 import (
 	"context"
 	"fmt"
+	"testing"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"testing"
+
+	"github.com/kozmod/oniontx-examples/internal/txtest"
 )
 
 const (
@@ -17,54 +20,69 @@ const (
 
 func Test_mockery(t *testing.T) {
 	t.Run("assert_success", func(t *testing.T) {
-		ctx := context.Background()
-		transactorMock := new(transactorMock)
-		transactorMock.On(transactorMethodWithinTx,
-			ctx,
-			mock.MatchedBy(func(i any) bool {
-				fn, ok := i.(func(context.Context) error)
-				assert.True(t, ok)
-				return assert.NoError(t, fn(ctx))
-			})).Return(nil)
+		var (
+			ctx        = context.Background()
+			transactor = txtest.NewTransactor()
+		)
 
 		repositoryMockA := new(repositoryMock)
-		repositoryMockA.On(repositoryMethodInsert, ctx, textValue).Return(nil)
+		repositoryMockA.On(repositoryMethodInsert, mock.Anything, textValue).Return(nil)
 
 		repositoryMockB := new(repositoryMock)
-		repositoryMockB.On(repositoryMethodInsert, ctx, textValue).Return(nil)
+		repositoryMockB.On(repositoryMethodInsert, mock.Anything, textValue).Return(nil)
 
 		useCase := UseCase{
-			transactor: transactorMock,
+			transactor: transactor,
 			textRepoA:  repositoryMockA,
 			textRepoB:  repositoryMockB,
 		}
 
 		err := useCase.CreateTextRecords(ctx, textValue)
 		assert.NoError(t, err)
+		transactor.AssertCalls(t, 1)
+		transactor.AssertCommitted(t)
+		repositoryMockA.AssertExpectations(t)
+		repositoryMockB.AssertExpectations(t)
 	})
 
 	t.Run("assert_error", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			expError   = fmt.Errorf("some_error")
+			transactor = txtest.NewTransactor()
+		)
+
+		repositoryMockA := new(repositoryMock)
+		repositoryMockA.On(repositoryMethodInsert, mock.Anything, textValue).Return(nil)
+
+		repositoryMockB := new(repositoryMock)
+		repositoryMockB.On(repositoryMethodInsert, mock.Anything, textValue).Return(expError)
+
+		useCase := UseCase{
+			transactor: transactor,
+			textRepoA:  repositoryMockA,
+			textRepoB:  repositoryMockB,
+		}
+
+		err := useCase.CreateTextRecords(ctx, textValue)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, expError)
+		transactor.AssertRolledBackWith(t, expError)
+		repositoryMockA.AssertExpectations(t)
+		repositoryMockB.AssertExpectations(t)
+	})
+	t.Run("assert_transactor_error", func(t *testing.T) {
 		var (
 			ctx           = context.Background()
-			expError      = fmt.Errorf("some_error")
-			trsnsactorErr = fmt.Errorf("transactor_error")
+			transactorErr = fmt.Errorf("transactor_error")
 		)
 
+		// the transactor fails before calling the function (e.g. on begin), so the repositories aren't called.
 		transactorMock := new(transactorMock)
-		transactorMock.On(transactorMethodWithinTx,
-			ctx,
-			mock.MatchedBy(func(i any) bool {
-				fn, ok := i.(func(context.Context) error)
-				assert.True(t, ok)
-				return assert.Error(t, fn(ctx))
-			}),
-		).Return(trsnsactorErr)
+		transactorMock.On(transactorMethodWithinTx, ctx, mock.Anything).Return(transactorErr)
 
 		repositoryMockA := new(repositoryMock)
-		repositoryMockA.On(repositoryMethodInsert, ctx, textValue).Return(nil)
-
 		repositoryMockB := new(repositoryMock)
-		repositoryMockB.On(repositoryMethodInsert, ctx, textValue).Return(expError)
 
 		useCase := UseCase{
 			transactor: transactorMock,
@@ -73,7 +91,9 @@ func Test_mockery(t *testing.T) {
 		}
 
 		err := useCase.CreateTextRecords(ctx, textValue)
-		assert.Error(t, err)
-		assert.ErrorIs(t, err, trsnsactorErr)
+		assert.ErrorIs(t, err, transactorErr)
+		transactorMock.AssertExpectations(t)
+		repositoryMockA.AssertNotCalled(t, repositoryMethodInsert, mock.Anything, mock.Anything)
+		repositoryMockB.AssertNotCalled(t, repositoryMethodInsert, mock.Anything, mock.Anything)
 	})
 }
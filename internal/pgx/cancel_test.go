@@ -0,0 +1,166 @@
+package pgx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	opgx "github.com/kozmod/oniontx/pgx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/testdb"
+	"github.com/kozmod/oniontx-examples/internal/usecase"
+)
+
+func Test_UseCase_Cancel(t *testing.T) {
+	t.Parallel()
+
+	var (
+		globalCtx = context.Background()
+		leaks     = testdb.NewLeakDetector(t)
+		schema    = testdb.NewSchema(t, leaks.ConnectionString())
+		db        = ConnectDB(globalCtx, t, schema.ConnectionString())
+	)
+
+	t.Cleanup(func() {
+		err := db.Close(globalCtx)
+		assert.NoError(t, err)
+	})
+
+	assertUsable := func(t *testing.T, conn *pgx.Conn) {
+		assert.False(t, conn.IsClosed())
+		assert.Equal(t, byte('I'), conn.PgConn().TxStatus())
+		assert.NoError(t, conn.Ping(globalCtx))
+	}
+
+	t.Run("cancel_between_inserts", func(t *testing.T) {
+		leaks.Watch(t)
+
+		var (
+			ctx, cancel = context.WithCancel(globalCtx)
+			transactor  = NewConnTransactor(db)
+			repositoryA = NewTextRepository(transactor)
+			repositoryB = testdb.InterruptTextRepository{
+				Repository: repositoryA,
+				Interrupt:  func(context.Context) { cancel() },
+			}
+			useCase = usecase.NewUseCase(repositoryA, repositoryB, transactor)
+		)
+		defer cancel()
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		{
+			records, err := GetTextRecords(globalCtx, db)
+			assert.NoError(t, err)
+			assert.Len(t, records, 0)
+			assertUsable(t, db)
+		}
+
+		t.Cleanup(func() {
+			err = ClearDB(globalCtx, db)
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("deadline_between_inserts", func(t *testing.T) {
+		leaks.Watch(t)
+
+		var (
+			ctx, cancel = context.WithTimeout(globalCtx, 100*time.Millisecond)
+			transactor  = NewConnTransactor(db)
+			repositoryA = NewTextRepository(transactor)
+			repositoryB = testdb.InterruptTextRepository{
+				Repository: repositoryA,
+				Interrupt:  func(ctx context.Context) { <-ctx.Done() },
+			}
+			useCases = usecase.NewUseCases(
+				transactor,
+				usecase.NewStep("A", usecase.NewUseCase(repositoryA, repositoryB, transactor)),
+				usecase.NewStep("B", usecase.NewUseCase(repositoryA, repositoryA, transactor)),
+			)
+		)
+		defer cancel()
+
+		err := useCases.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		{
+			records, err := GetTextRecords(globalCtx, db)
+			assert.NoError(t, err)
+			assert.Len(t, records, 0)
+			assertUsable(t, db)
+		}
+
+		t.Cleanup(func() {
+			err = ClearDB(globalCtx, db)
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("cancel_between_inserts_pool", func(t *testing.T) {
+		leaks.Watch(t)
+
+		var (
+			ctx, cancel = context.WithCancel(globalCtx)
+			pool        = ConnectPool(globalCtx, t, schema.ConnectionString(), 2)
+			transactor  = NewPoolTransactor(pool)
+			repositoryA = NewTextRepository(transactor)
+			repositoryB = testdb.InterruptTextRepository{
+				Repository: repositoryA,
+				Interrupt:  func(context.Context) { cancel() },
+			}
+			useCase = usecase.NewUseCase(repositoryA, repositoryB, transactor)
+		)
+		defer cancel()
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		{
+			records, err := GetTextRecords(globalCtx, pool)
+			assert.NoError(t, err)
+			assert.Len(t, records, 0)
+			assert.Zero(t, pool.Stat().AcquiredConns())
+			assert.NoError(t, pool.Ping(globalCtx))
+		}
+
+		t.Cleanup(func() {
+			err = ClearDB(globalCtx, pool)
+			assert.NoError(t, err)
+			pool.Close()
+		})
+	})
+	t.Run("opgx_transactor_closes_connection", func(t *testing.T) {
+		leaks.Watch(t)
+
+		var (
+			ctx, cancel = context.WithCancel(globalCtx)
+			conn        = ConnectDB(globalCtx, t, schema.ConnectionString())
+			transactor  = opgx.NewTransactor(conn)
+			repositoryA = NewTextRepository(transactor)
+			repositoryB = testdb.InterruptTextRepository{
+				Repository: repositoryA,
+				Interrupt:  func(context.Context) { cancel() },
+			}
+			useCase = usecase.NewUseCase(repositoryA, repositoryB, transactor)
+		)
+		defer cancel()
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		{
+			records, err := GetTextRecords(globalCtx, db)
+			assert.NoError(t, err)
+			assert.Len(t, records, 0)
+			// the rollback is sent with the cancelled context, so the connection is closed.
+			assert.True(t, conn.IsClosed())
+		}
+
+		t.Cleanup(func() {
+			err = ClearDB(globalCtx, db)
+			assert.NoError(t, err)
+		})
+	})
+}
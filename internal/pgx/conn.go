@@ -0,0 +1,72 @@
+package pgx
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kozmod/oniontx"
+	opgx "github.com/kozmod/oniontx/pgx"
+)
+
+// txConn is the connection of ConnTransactor, e.g. [pgx.Conn] or its mock (pgxmock.PgxConnIface).
+type txConn interface {
+	opgx.Executor
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+}
+
+// connWrapper wraps txConn and implements [oniontx.TxBeginner].
+type connWrapper struct {
+	txConn
+}
+
+// BeginTx starts a transaction.
+func (w *connWrapper) BeginTx(ctx context.Context, opts ...oniontx.Option[*pgx.TxOptions]) (*txWrapper, error) {
+	var txOptions pgx.TxOptions
+	for _, opt := range opts {
+		opt.Apply(&txOptions)
+	}
+	tx, err := w.txConn.BeginTx(ctx, txOptions)
+	return &txWrapper{Tx: tx}, err
+}
+
+// ConnTransactor manage a transaction for single connection ([pgx.Conn] or its mock).
+//
+// Unlike [opgx.Transactor], ConnTransactor keeps the connection alive
+// when the context is cancelled within the transaction.
+type ConnTransactor struct {
+	*oniontx.Transactor[*connWrapper, *txWrapper, *pgx.TxOptions]
+}
+
+// NewConnTransactor returns new ConnTransactor.
+func NewConnTransactor(conn txConn) *ConnTransactor {
+	var (
+		base       = connWrapper{txConn: conn}
+		operator   = oniontx.NewContextOperator[*connWrapper, *txWrapper](&base)
+		transactor = oniontx.NewTransactor[*connWrapper, *txWrapper, *pgx.TxOptions](&base, operator)
+	)
+	return &ConnTransactor{
+		Transactor: transactor,
+	}
+}
+
+// TryGetTx returns [pgx.Tx] and "true" from [context.Context] or return `false`.
+func (t *ConnTransactor) TryGetTx(ctx context.Context) (pgx.Tx, bool) {
+	wrapper, ok := t.Transactor.TryGetTx(ctx)
+	if !ok || wrapper == nil || wrapper.Tx == nil {
+		return nil, false
+	}
+	return wrapper.Tx, true
+}
+
+// TxBeginner returns the connection.
+func (t *ConnTransactor) TxBeginner() txConn {
+	return t.Transactor.TxBeginner().txConn
+}
+
+// GetExecutor returns [opgx.Executor] implementation ([pgx.Tx] or the connection).
+func (t *ConnTransactor) GetExecutor(ctx context.Context) opgx.Executor {
+	if tx, ok := t.TryGetTx(ctx); ok {
+		return tx
+	}
+	return t.TxBeginner()
+}
@@ -6,13 +6,21 @@ import (
 	"testing"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kozmod/oniontx"
+	opgx "github.com/kozmod/oniontx/pgx"
+	"github.com/pashagolub/pgxmock/v3"
 	"github.com/stretchr/testify/assert"
-
-	"github.com/kozmod/oniontx-examples/internal/entity"
 )
 
-func ConnectDB(ctx context.Context, t *testing.T) *pgx.Conn {
-	conn, err := pgx.Connect(ctx, entity.ConnectionString)
+type executor interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+func ConnectDB(ctx context.Context, t *testing.T, connString string) *pgx.Conn {
+	conn, err := pgx.Connect(ctx, connString)
 	assert.NoError(t, err)
 
 	err = conn.Ping(ctx)
@@ -20,7 +28,79 @@ func ConnectDB(ctx context.Context, t *testing.T) *pgx.Conn {
 	return conn
 }
 
-func ClearDB(ctx context.Context, db *pgx.Conn) error {
+// NewPgxMock returns [pgxmock.PgxConnIface], which matches the expected SQL exactly and in order.
+func NewPgxMock(t *testing.T) pgxmock.PgxConnIface {
+	conn, err := pgxmock.NewConn(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, conn.ExpectationsWereMet())
+	})
+	return conn
+}
+
+func ConnectPool(ctx context.Context, t *testing.T, connString string, maxConns int32, opts ...func(config *pgxpool.Config)) *pgxpool.Pool {
+	config, err := pgxpool.ParseConfig(connString)
+	assert.NoError(t, err)
+
+	config.MaxConns = maxConns
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	assert.NoError(t, err)
+
+	err = pool.Ping(ctx)
+	assert.NoError(t, err)
+	return pool
+}
+
+// outerTxWrapper wraps the test's outer [pgx.Tx] and implements [oniontx.TxBeginner].
+// Each transaction begun by outerTxWrapper is a pseudo nested transaction (savepoint) of the outer transaction.
+type outerTxWrapper struct {
+	pgx.Tx
+}
+
+// BeginTx creates a savepoint by [pgx.Tx.Begin], [pgx.TxOptions] are ignored.
+func (w *outerTxWrapper) BeginTx(ctx context.Context, _ ...oniontx.Option[*pgx.TxOptions]) (*txWrapper, error) {
+	tx, err := w.Tx.Begin(ctx)
+	return &txWrapper{Tx: tx}, err
+}
+
+// RollbackTransactor manages transactions as savepoints of the test's outer [pgx.Tx].
+//
+// Commit of the savepoint releases it, Rollback rolls back to it (look at [pgx.Tx]).
+type RollbackTransactor struct {
+	*oniontx.Transactor[*outerTxWrapper, *txWrapper, *pgx.TxOptions]
+}
+
+// NewRollbackTransactor begins the test's outer transaction and returns RollbackTransactor bound to it.
+// The outer transaction is rolled back in the test's cleanup, so the test leaves no records.
+func NewRollbackTransactor(ctx context.Context, t *testing.T, conn txConn) *RollbackTransactor {
+	tx, err := conn.BeginTx(ctx, pgx.TxOptions{})
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, tx.Rollback(context.Background()))
+	})
+
+	var (
+		base       = outerTxWrapper{Tx: tx}
+		operator   = oniontx.NewContextOperator[*outerTxWrapper, *txWrapper](&base)
+		transactor = oniontx.NewTransactor[*outerTxWrapper, *txWrapper, *pgx.TxOptions](&base, operator)
+	)
+	return &RollbackTransactor{
+		Transactor: transactor,
+	}
+}
+
+// GetExecutor returns the test's outer [pgx.Tx], which is the executor of the savepoints too.
+func (t *RollbackTransactor) GetExecutor(_ context.Context) opgx.Executor {
+	return t.Transactor.TxBeginner().Tx
+}
+
+func ClearDB(ctx context.Context, db executor) error {
 	_, err := db.Exec(ctx, `TRUNCATE TABLE text;`)
 	if err != nil {
 		return fmt.Errorf("clear DB: %w", err)
@@ -28,7 +108,7 @@ func ClearDB(ctx context.Context, db *pgx.Conn) error {
 	return nil
 }
 
-func GetTextRecords(ctx context.Context, db *pgx.Conn) ([]string, error) {
+func GetTextRecords(ctx context.Context, db executor) ([]string, error) {
 	row, err := db.Query(ctx, "SELECT val FROM text;")
 	if err != nil {
 		return nil, fmt.Errorf("get `text` records: %w", err)
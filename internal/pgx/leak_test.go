@@ -0,0 +1,57 @@
+package pgx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kozmod/oniontx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/testdb"
+)
+
+func Test_LeakDetection(t *testing.T) {
+	t.Parallel()
+
+	var (
+		globalCtx = context.Background()
+		leaks     = testdb.NewLeakDetector(t)
+		schema    = testdb.NewSchema(t, leaks.ConnectionString())
+		db        = ConnectDB(globalCtx, t, schema.ConnectionString())
+	)
+
+	t.Cleanup(func() {
+		err := db.Close(globalCtx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("nested_panic_and_rollback", func(t *testing.T) {
+		leaks.Watch(t)
+
+		var (
+			ctx        = context.Background()
+			transactor = NewConnTransactor(db)
+			repository = NewTextRepository(transactor)
+		)
+
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			err := repository.Insert(ctx, textRecord)
+			assert.NoError(t, err)
+			return transactor.WithinTx(ctx, func(ctx context.Context) error {
+				panic("some panic")
+			})
+		})
+		assert.ErrorIs(t, err, oniontx.ErrRollbackSuccess)
+
+		{
+			records, err := GetTextRecords(globalCtx, db)
+			assert.NoError(t, err)
+			assert.Len(t, records, 0)
+		}
+
+		t.Cleanup(func() {
+			err = ClearDB(globalCtx, db)
+			assert.NoError(t, err)
+		})
+	})
+}
@@ -0,0 +1,262 @@
+package pgx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kozmod/oniontx"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+	"github.com/kozmod/oniontx-examples/internal/usecase"
+)
+
+const (
+	insertQuery = `INSERT INTO text (val) VALUES ($1)`
+)
+
+// cancelUseCase cancels the context of the transaction.
+type cancelUseCase struct {
+	cancel context.CancelFunc
+}
+
+func (u cancelUseCase) CreateTextRecords(context.Context, string) error {
+	u.cancel()
+	return nil
+}
+
+func Test_UseCase_PgxMock(t *testing.T) {
+	t.Run("success_create", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			conn       = NewPgxMock(t)
+			transactor = NewConnTransactor(conn)
+			repository = NewTextRepository(transactor)
+			useCase    = usecase.NewUseCase(repository, repository, transactor)
+		)
+
+		conn.ExpectBegin()
+		conn.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		conn.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		conn.ExpectCommit()
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+	})
+	t.Run("error_and_rollback", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			conn       = NewPgxMock(t)
+			transactor = NewConnTransactor(conn)
+			repository = NewTextRepository(transactor)
+			useCase    = usecase.NewUseCase(repository, repository, transactor)
+		)
+
+		conn.ExpectBegin()
+		conn.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		conn.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnError(entity.ErrExpected)
+		conn.ExpectRollback()
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.ErrorIs(t, err, oniontx.ErrRollbackSuccess)
+	})
+	t.Run("begin_error", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			conn       = NewPgxMock(t)
+			transactor = NewConnTransactor(conn)
+			repository = NewTextRepository(transactor)
+			useCase    = usecase.NewUseCase(repository, repository, transactor)
+		)
+
+		conn.ExpectBegin().WillReturnError(entity.ErrExpected)
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.ErrorIs(t, err, oniontx.ErrBeginTx)
+	})
+	t.Run("commit_error", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			conn       = NewPgxMock(t)
+			transactor = NewConnTransactor(conn)
+			repository = NewTextRepository(transactor)
+			useCase    = usecase.NewUseCase(repository, repository, transactor)
+		)
+
+		conn.ExpectBegin()
+		conn.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		conn.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		conn.ExpectCommit().WillReturnError(entity.ErrExpected)
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.ErrorIs(t, err, oniontx.ErrCommitFailed)
+	})
+	t.Run("rollback_error", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			conn       = NewPgxMock(t)
+			transactor = NewConnTransactor(conn)
+			repository = NewTextRepository(transactor)
+			useCase    = usecase.NewUseCase(repository, repository, transactor)
+			rbErr      = context.DeadlineExceeded
+		)
+
+		conn.ExpectBegin()
+		conn.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnError(entity.ErrExpected)
+		conn.ExpectRollback().WillReturnError(rbErr)
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.ErrorIs(t, err, rbErr)
+		assert.ErrorIs(t, err, oniontx.ErrRollbackFailed)
+	})
+}
+
+func Test_UseCases_PgxMock(t *testing.T) {
+	t.Run("success_create", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			conn       = NewPgxMock(t)
+			transactor = NewConnTransactor(conn)
+			repository = NewTextRepository(transactor)
+			useCases   = usecase.NewUseCases(
+				transactor,
+				usecase.NewStep("A", usecase.NewUseCase(repository, repository, transactor)),
+				usecase.NewStep("B", usecase.NewUseCase(repository, repository, transactor)),
+			)
+		)
+
+		// only the root transaction begins and commits.
+		conn.ExpectBegin()
+		for i := 0; i < 4; i++ {
+			conn.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		}
+		conn.ExpectCommit()
+
+		err := useCases.CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+	})
+	t.Run("error_and_rollback", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			conn       = NewPgxMock(t)
+			transactor = NewConnTransactor(conn)
+			repository = NewTextRepository(transactor)
+			useCases   = usecase.NewUseCases(
+				transactor,
+				usecase.NewStep("A", usecase.NewUseCase(repository, repository, transactor)),
+				usecase.NewStep("B", usecase.NewUseCase(repository, repository, transactor)),
+			)
+		)
+
+		conn.ExpectBegin()
+		conn.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		conn.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		conn.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnError(entity.ErrExpected)
+		conn.ExpectRollback()
+
+		err := useCases.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.ErrorContains(t, err, "text usecase B")
+		assert.ErrorIs(t, err, oniontx.ErrRollbackSuccess)
+	})
+	t.Run("commit_error", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			conn       = NewPgxMock(t)
+			transactor = NewConnTransactor(conn)
+			repository = NewTextRepository(transactor)
+			useCases   = usecase.NewUseCases(
+				transactor,
+				usecase.NewStep("A", usecase.NewUseCase(repository, repository, transactor)),
+				usecase.NewStep("B", usecase.NewUseCase(repository, repository, transactor)),
+			)
+		)
+
+		conn.ExpectBegin()
+		for i := 0; i < 4; i++ {
+			conn.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		}
+		conn.ExpectCommit().WillReturnError(entity.ErrExpected)
+
+		err := useCases.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.ErrorIs(t, err, oniontx.ErrCommitFailed)
+	})
+	t.Run("canceled_before_commit", func(t *testing.T) {
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			conn        = NewPgxMock(t)
+			transactor  = NewConnTransactor(conn)
+			repository  = NewTextRepository(transactor)
+			useCases    = usecase.NewUseCases(
+				transactor,
+				usecase.NewStep("A", usecase.NewUseCase(repository, repository, transactor)),
+				usecase.NewStep[string]("cancel", cancelUseCase{cancel: cancel}),
+			)
+		)
+		defer cancel()
+
+		// the transaction is rolled back instead of commit when the context is done.
+		conn.ExpectBegin()
+		conn.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		conn.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		conn.ExpectRollback()
+
+		err := useCases.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorIs(t, err, oniontx.ErrCommitFailed)
+	})
+}
+
+func Test_RollbackTransactor_PgxMock(t *testing.T) {
+	t.Run("release_savepoint", func(t *testing.T) {
+		var (
+			ctx  = context.Background()
+			conn = NewPgxMock(t)
+		)
+
+		conn.ExpectBegin()
+		conn.ExpectBegin()
+		conn.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		conn.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		conn.ExpectCommit()
+		conn.ExpectRollback()
+
+		var (
+			transactor = NewRollbackTransactor(ctx, t, conn)
+			repository = NewTextRepository(transactor)
+			useCase    = usecase.NewUseCase(repository, repository, transactor)
+		)
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+	})
+	t.Run("rollback_to_savepoint", func(t *testing.T) {
+		var (
+			ctx  = context.Background()
+			conn = NewPgxMock(t)
+		)
+
+		conn.ExpectBegin()
+		conn.ExpectBegin()
+		conn.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		conn.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnError(entity.ErrExpected)
+		conn.ExpectRollback()
+		conn.ExpectRollback()
+
+		var (
+			transactor = NewRollbackTransactor(ctx, t, conn)
+			repository = NewTextRepository(transactor)
+			useCase    = usecase.NewUseCase(repository, repository, transactor)
+		)
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.ErrorIs(t, err, oniontx.ErrRollbackSuccess)
+	})
+}
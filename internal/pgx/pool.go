@@ -0,0 +1,72 @@
+package pgx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kozmod/oniontx"
+	opgx "github.com/kozmod/oniontx/pgx"
+)
+
+// poolWrapper wraps [pgxpool.Pool] and implements [oniontx.TxBeginner].
+type poolWrapper struct {
+	*pgxpool.Pool
+}
+
+// BeginTx acquires a connection from the pool and starts a transaction.
+func (w *poolWrapper) BeginTx(ctx context.Context, opts ...oniontx.Option[*pgx.TxOptions]) (*txWrapper, error) {
+	var txOptions pgx.TxOptions
+	for _, opt := range opts {
+		opt.Apply(&txOptions)
+	}
+	tx, err := w.Pool.BeginTx(ctx, txOptions)
+	return &txWrapper{Tx: tx}, err
+}
+
+// Prepare is not supported by [pgxpool.Pool], since a prepared statement belongs to a single connection.
+func (w *poolWrapper) Prepare(_ context.Context, name, _ string) (*pgconn.StatementDescription, error) {
+	return nil, fmt.Errorf("pgx pool - prepare [%s]: %w", name, errors.ErrUnsupported)
+}
+
+// PoolTransactor manage a transaction for single [pgxpool.Pool] instance.
+type PoolTransactor struct {
+	*oniontx.Transactor[*poolWrapper, *txWrapper, *pgx.TxOptions]
+}
+
+// NewPoolTransactor returns new PoolTransactor.
+func NewPoolTransactor(pool *pgxpool.Pool) *PoolTransactor {
+	var (
+		base       = poolWrapper{Pool: pool}
+		operator   = oniontx.NewContextOperator[*poolWrapper, *txWrapper](&base)
+		transactor = oniontx.NewTransactor[*poolWrapper, *txWrapper, *pgx.TxOptions](&base, operator)
+	)
+	return &PoolTransactor{
+		Transactor: transactor,
+	}
+}
+
+// TryGetTx returns [pgx.Tx] and "true" from [context.Context] or return `false`.
+func (t *PoolTransactor) TryGetTx(ctx context.Context) (pgx.Tx, bool) {
+	wrapper, ok := t.Transactor.TryGetTx(ctx)
+	if !ok || wrapper == nil || wrapper.Tx == nil {
+		return nil, false
+	}
+	return wrapper.Tx, true
+}
+
+// TxBeginner returns pointer of [pgxpool.Pool].
+func (t *PoolTransactor) TxBeginner() *pgxpool.Pool {
+	return t.Transactor.TxBeginner().Pool
+}
+
+// GetExecutor returns [opgx.Executor] implementation ([pgx.Tx] or [pgxpool.Pool] wrapper).
+func (t *PoolTransactor) GetExecutor(ctx context.Context) opgx.Executor {
+	if tx, ok := t.TryGetTx(ctx); ok {
+		return tx
+	}
+	return t.Transactor.TxBeginner()
+}
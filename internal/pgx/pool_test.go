@@ -0,0 +1,155 @@
+package pgx
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+	"github.com/kozmod/oniontx-examples/internal/fault"
+	"github.com/kozmod/oniontx-examples/internal/testdb"
+	"github.com/kozmod/oniontx-examples/internal/usecase"
+)
+
+func Test_PoolTransactor_UseCases(t *testing.T) {
+	t.Parallel()
+
+	const (
+		maxConns = 8
+		calls    = 300
+		failEach = 5
+	)
+
+	var (
+		globalCtx = context.Background()
+		leaks     = testdb.NewLeakDetector(t)
+		schema    = testdb.NewSchema(t, leaks.ConnectionString())
+		pool      = ConnectPool(globalCtx, t, schema.ConnectionString(), maxConns)
+	)
+
+	t.Cleanup(func() {
+		pool.Close()
+	})
+
+	t.Run("concurrent_create", func(t *testing.T) {
+		var (
+			transactor   = NewPoolTransactor(pool)
+			repositoryA  = NewTextRepository(transactor)
+			repositoryB  = NewTextRepository(transactor)
+			injector     = fault.NewInjector(fault.Rule{Point: fault.BeforeExec, Err: entity.ErrExpected})
+			repositoryEr = NewTextRepository(fault.NewPgxRepoTransactor(transactor, injector))
+
+			useCases = usecase.NewUseCases(
+				transactor,
+				usecase.NewStep("A", usecase.NewUseCase(repositoryA, repositoryB, transactor)),
+				usecase.NewStep("B", usecase.NewUseCase(repositoryA, repositoryB, transactor)),
+			)
+			failedUseCases = usecase.NewUseCases(
+				transactor,
+				usecase.NewStep("A", usecase.NewUseCase(repositoryA, repositoryEr, transactor)),
+				usecase.NewStep("B", usecase.NewUseCase(repositoryA, repositoryEr, transactor)),
+			)
+
+			wg          sync.WaitGroup
+			done        = make(chan struct{})
+			maxAcquired atomic.Int32
+			before      = pool.Stat()
+		)
+
+		go func() {
+			ticker := time.NewTicker(time.Millisecond)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-done:
+					return
+				case <-ticker.C:
+					if acquired := pool.Stat().AcquiredConns(); acquired > maxAcquired.Load() {
+						maxAcquired.Store(acquired)
+					}
+				}
+			}
+		}()
+
+		for i := 0; i < calls; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				var (
+					ctx  = context.Background()
+					text = fmt.Sprintf("text_%d", i)
+				)
+
+				if i%failEach == 0 {
+					err := failedUseCases.CreateTextRecords(ctx, text)
+					assert.ErrorIs(t, err, entity.ErrExpected)
+					return
+				}
+
+				err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+					err := useCases.CreateTextRecords(ctx, text)
+					if err != nil {
+						return err
+					}
+
+					// all statements of the transaction are executed on the same connection,
+					// so the transaction sees its own records and nothing else.
+					records, err := GetTextRecords(ctx, transactor.GetExecutor(ctx))
+					if err != nil {
+						return err
+					}
+					var own int
+					for _, record := range records {
+						if record == text {
+							own++
+						}
+					}
+					assert.Equal(t, 4, own)
+					return nil
+				})
+				assert.NoError(t, err)
+			}(i)
+		}
+		wg.Wait()
+		close(done)
+
+		// the goroutines outnumber the connections, so the transactions wait for the acquired connections' release:
+		// more acquires find the pool empty than the constructing of maxConns connections explains.
+		stat := pool.Stat()
+		assert.Positive(t, maxAcquired.Load())
+		assert.LessOrEqual(t, maxAcquired.Load(), int32(maxConns))
+		assert.Greater(t, stat.EmptyAcquireCount()-before.EmptyAcquireCount(), int64(maxConns))
+		assert.Positive(t, stat.AcquireDuration()-before.AcquireDuration())
+		assert.Equal(t, int32(0), stat.AcquiredConns())
+
+		leaks.AssertNoIdleInTx(t)
+
+		{
+			records, err := GetTextRecords(globalCtx, pool)
+			assert.NoError(t, err)
+
+			counts := make(map[string]int, calls)
+			for _, record := range records {
+				counts[record]++
+			}
+			for i := 0; i < calls; i++ {
+				text := fmt.Sprintf("text_%d", i)
+				if i%failEach == 0 {
+					assert.Zero(t, counts[text], text)
+					continue
+				}
+				assert.Equal(t, 4, counts[text], text)
+			}
+		}
+
+		t.Cleanup(func() {
+			err := ClearDB(globalCtx, pool)
+			assert.NoError(t, err)
+		})
+	})
+}
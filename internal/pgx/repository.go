@@ -15,25 +15,20 @@ type (
 )
 
 type TextRepository struct {
-	transactor    repoTransactor
-	errorExpected bool
+	transactor repoTransactor
 }
 
-func NewTextRepository(transactor repoTransactor, errorExpected bool) *TextRepository {
+func NewTextRepository(transactor repoTransactor) *TextRepository {
 	return &TextRepository{
-		transactor:    transactor,
-		errorExpected: errorExpected,
+		transactor: transactor,
 	}
 }
 
 func (r *TextRepository) Insert(ctx context.Context, val string) error {
-	if r.errorExpected {
-		return entity.ErrExpected
-	}
 	ex := r.transactor.GetExecutor(ctx)
 	_, err := ex.Exec(ctx, `INSERT INTO text (val) VALUES ($1)`, val)
 	if err != nil {
-		return fmt.Errorf("pgx repository - raw insert: %w", err)
+		return fmt.Errorf("pgx repository - raw insert: %w", entity.MapError(err))
 	}
 	return nil
 }
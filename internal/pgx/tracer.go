@@ -0,0 +1,145 @@
+package pgx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	opgx "github.com/kozmod/oniontx/pgx"
+
+	"github.com/kozmod/oniontx-examples/internal/txid"
+)
+
+const (
+	txStatusIdle = 'I'
+)
+
+// txControlCommands are the first words of the transaction control statements,
+// [pgx.Tx] sends BEGIN, COMMIT, ROLLBACK and savepoints' statements as regular queries.
+var txControlCommands = map[string]struct{}{
+	"begin":     {},
+	"start":     {},
+	"commit":    {},
+	"end":       {},
+	"rollback":  {},
+	"abort":     {},
+	"savepoint": {},
+	"release":   {},
+}
+
+type (
+	tagTransactor interface {
+		WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error)
+		GetExecutor(ctx context.Context) opgx.Executor
+	}
+
+	pgTxIDKey   struct{}
+	queryKey    struct{}
+	queryRecord struct {
+		start time.Time
+		QueryRecord
+	}
+)
+
+// TxTagger decorates a transactor and tags transactions for [QueryTracer].
+//
+// The highest level WithinTx call obtains (or generates) the transaction ID
+// and requests `txid_current()` of the started transaction.
+// NOTE: `txid_current()` assigns the transaction ID even for read-only transactions.
+type TxTagger struct {
+	transactor tagTransactor
+}
+
+// NewTxTagger returns new TxTagger.
+func NewTxTagger(transactor tagTransactor) *TxTagger {
+	return &TxTagger{
+		transactor: transactor,
+	}
+}
+
+// WithinTx calls WithinTx of the decorated transactor with the tagged [context.Context].
+func (t *TxTagger) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(pgTxIDKey{}).(int64); ok {
+		return t.transactor.WithinTx(ctx, fn)
+	}
+	if _, ok := txid.Extract(ctx); !ok {
+		ctx = txid.Inject(ctx, txid.New())
+	}
+	return t.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		var pgTxID int64
+		err := t.transactor.GetExecutor(ctx).QueryRow(ctx, `SELECT txid_current()`).Scan(&pgTxID)
+		if err != nil {
+			return fmt.Errorf("pgx tx tagger - get current txid: %w", err)
+		}
+		return fn(context.WithValue(ctx, pgTxIDKey{}, pgTxID))
+	})
+}
+
+// QueryRecord contains information about an executed query.
+type QueryRecord struct {
+	// TxID is the ID of the WithinTx call ([txid.Extract]) or empty.
+	TxID string
+	// PgTxID is `txid_current()` of the transaction or 0.
+	PgTxID int64
+	SQL    string
+	// Duration of the query.
+	Duration time.Duration
+	Err      error
+	// TxControl marks the transaction control statement (e.g. BEGIN or COMMIT).
+	TxControl bool
+	// OutsideTx marks the query executed outside a transaction,
+	// while [context.Context] carries the transaction ID.
+	// Transaction control statements are never marked, since BEGIN is executed outside the transaction it starts.
+	OutsideTx bool
+}
+
+// QueryTracer implements [pgx.QueryTracer] and passes a [QueryRecord] of each query to the handler.
+type QueryTracer struct {
+	handler func(ctx context.Context, record QueryRecord)
+}
+
+// NewQueryTracer returns new QueryTracer.
+func NewQueryTracer(handler func(ctx context.Context, record QueryRecord)) *QueryTracer {
+	return &QueryTracer{
+		handler: handler,
+	}
+}
+
+// TraceQueryStart is called at the beginning of Query, QueryRow, and Exec calls.
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	var (
+		id, ok    = txid.Extract(ctx)
+		pgTxID, _ = ctx.Value(pgTxIDKey{}).(int64)
+		txControl = isTxControl(data.SQL)
+		record    = queryRecord{
+			start: time.Now(),
+			QueryRecord: QueryRecord{
+				TxID:      id,
+				PgTxID:    pgTxID,
+				SQL:       data.SQL,
+				TxControl: txControl,
+				OutsideTx: ok && !txControl && conn.PgConn().TxStatus() == txStatusIdle,
+			},
+		}
+	)
+	return context.WithValue(ctx, queryKey{}, record)
+}
+
+// TraceQueryEnd is called at the end of Query, QueryRow, and Exec calls.
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	record, ok := ctx.Value(queryKey{}).(queryRecord)
+	if !ok {
+		return
+	}
+	record.Duration = time.Since(record.start)
+	record.Err = data.Err
+	t.handler(ctx, record.QueryRecord)
+}
+
+func isTxControl(sql string) bool {
+	command, _, _ := strings.Cut(strings.TrimSpace(sql), " ")
+	_, ok := txControlCommands[strings.ToLower(command)]
+	return ok
+}
@@ -0,0 +1,164 @@
+package pgx
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/testdb"
+	"github.com/kozmod/oniontx-examples/internal/usecase"
+)
+
+type queryRecorder struct {
+	mx      sync.Mutex
+	records []QueryRecord
+}
+
+func (r *queryRecorder) handle(_ context.Context, record QueryRecord) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	r.records = append(r.records, record)
+}
+
+func (r *queryRecorder) Records() []QueryRecord {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	return append([]QueryRecord(nil), r.records...)
+}
+
+func Test_QueryTracer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		globalCtx = context.Background()
+		recorder  = new(queryRecorder)
+		leaks     = testdb.NewLeakDetector(t)
+		schema    = testdb.NewSchema(t, leaks.ConnectionString())
+		pool      = ConnectPool(globalCtx, t, schema.ConnectionString(), 2, func(config *pgxpool.Config) {
+			config.ConnConfig.Tracer = NewQueryTracer(recorder.handle)
+		})
+	)
+
+	t.Cleanup(func() {
+		pool.Close()
+	})
+
+	t.Run("queries_tagged_with_tx", func(t *testing.T) {
+		leaks.Watch(t)
+
+		var (
+			ctx         = context.Background()
+			transactor  = NewPoolTransactor(pool)
+			repositoryA = NewTextRepository(transactor)
+			repositoryB = NewTextRepository(transactor)
+			useCase     = usecase.NewUseCase(repositoryA, repositoryB, NewTxTagger(transactor))
+		)
+
+		start := len(recorder.Records())
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+
+		// pgx sends BEGIN and COMMIT as regular queries
+		records := recorder.Records()[start:]
+		if !assert.Len(t, records, 5) {
+			return
+		}
+
+		var (
+			begin   = records[0]
+			current = records[1]
+			commit  = records[4]
+		)
+		assert.Equal(t, "begin", begin.SQL)
+		assert.True(t, begin.TxControl)
+		assert.False(t, begin.OutsideTx)
+		assert.NotEmpty(t, begin.TxID)
+
+		assert.Contains(t, current.SQL, "txid_current")
+		assert.Equal(t, begin.TxID, current.TxID)
+		assert.False(t, current.TxControl)
+		assert.False(t, current.OutsideTx)
+
+		for _, record := range records[2:4] {
+			assert.Contains(t, record.SQL, "INSERT")
+			assert.Equal(t, begin.TxID, record.TxID)
+			assert.Positive(t, record.PgTxID)
+			assert.Positive(t, record.Duration)
+			assert.False(t, record.TxControl)
+			assert.False(t, record.OutsideTx)
+			assert.NoError(t, record.Err)
+		}
+
+		assert.Equal(t, "commit", commit.SQL)
+		assert.True(t, commit.TxControl)
+		assert.False(t, commit.OutsideTx)
+		assert.Equal(t, begin.TxID, commit.TxID)
+
+		t.Cleanup(func() {
+			err = ClearDB(globalCtx, pool)
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("query_outside_tx", func(t *testing.T) {
+		leaks.Watch(t)
+
+		var (
+			ctx        = context.Background()
+			transactor = NewTxTagger(NewPoolTransactor(pool))
+		)
+
+		start := len(recorder.Records())
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			// the pool executes the query on another connection
+			_, err := pool.Exec(ctx, `SELECT 1`)
+			return err
+		})
+		assert.NoError(t, err)
+
+		// begin, txid_current, the query on another connection and commit
+		records := recorder.Records()[start:]
+		if !assert.Len(t, records, 4) {
+			return
+		}
+		for i, outsideTx := range []bool{false, false, true, false} {
+			assert.Equal(t, outsideTx, records[i].OutsideTx, records[i].SQL)
+			assert.Equal(t, records[0].TxID, records[i].TxID)
+		}
+		assert.Equal(t, "SELECT 1", records[2].SQL)
+	})
+	t.Run("query_without_tx", func(t *testing.T) {
+		leaks.Watch(t)
+
+		ctx := context.Background()
+
+		start := len(recorder.Records())
+		_, err := pool.Exec(ctx, `SELECT 1`)
+		assert.NoError(t, err)
+
+		records := recorder.Records()[start:]
+		assert.Len(t, records, 1)
+		assert.Empty(t, records[0].TxID)
+		assert.Zero(t, records[0].PgTxID)
+		assert.False(t, records[0].OutsideTx)
+	})
+}
+
+func Test_isTxControl(t *testing.T) {
+	for sql, exp := range map[string]bool{
+		"begin":                              true,
+		"begin isolation level serializable": true,
+		"commit":                             true,
+		"rollback":                           true,
+		"savepoint sp_1":                     true,
+		"release savepoint sp_1":             true,
+		"rollback to savepoint sp_1":         true,
+		"  COMMIT":                           true,
+		"SELECT txid_current()":              false,
+		"INSERT INTO text (val) VALUES ($1)": false,
+	} {
+		assert.Equal(t, exp, isTxControl(sql), sql)
+	}
+}
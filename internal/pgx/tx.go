@@ -0,0 +1,33 @@
+package pgx
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// txWrapper wraps [pgx.Tx] and implements [oniontx.Tx].
+//
+// [pgx.Tx] sends ROLLBACK and COMMIT with the passed context and closes the connection
+// when the context is already done, so txWrapper never passes the cancellation to the connection.
+type txWrapper struct {
+	pgx.Tx
+}
+
+// Rollback aborts the transaction even if the context is already done.
+func (t *txWrapper) Rollback(ctx context.Context) error {
+	return t.Tx.Rollback(context.WithoutCancel(ctx))
+}
+
+// Commit commits the transaction.
+// When the context is already done, Commit aborts the transaction and returns the context's error.
+func (t *txWrapper) Commit(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		if rbErr := t.Rollback(ctx); rbErr != nil {
+			return errors.Join(err, rbErr)
+		}
+		return err
+	}
+	return t.Tx.Commit(context.WithoutCancel(ctx))
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"testing"
 
-	opgx "github.com/kozmod/oniontx/pgx"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/kozmod/oniontx-examples/internal/entity"
+	"github.com/kozmod/oniontx-examples/internal/fault"
+	"github.com/kozmod/oniontx-examples/internal/testdb"
+	"github.com/kozmod/oniontx-examples/internal/usecase"
 )
 
 const (
@@ -15,9 +17,13 @@ const (
 )
 
 func Test_UseCase_CreateTextRecords(t *testing.T) {
+	t.Parallel()
+
 	var (
 		globalCtx = context.Background()
-		db        = ConnectDB(globalCtx, t)
+		leaks     = testdb.NewLeakDetector(t)
+		schema    = testdb.NewSchema(t, leaks.ConnectionString())
+		db        = ConnectDB(globalCtx, t, schema.ConnectionString())
 	)
 
 	t.Cleanup(func() {
@@ -26,12 +32,14 @@ func Test_UseCase_CreateTextRecords(t *testing.T) {
 	})
 
 	t.Run("success_create", func(t *testing.T) {
+		leaks.Watch(t)
+
 		var (
 			ctx         = context.Background()
-			transactor  = opgx.NewTransactor(db)
-			repositoryA = NewTextRepository(transactor, false)
-			repositoryB = NewTextRepository(transactor, false)
-			useCase     = NewUseCase(repositoryA, repositoryB, transactor)
+			transactor  = NewConnTransactor(db)
+			repositoryA = NewTextRepository(transactor)
+			repositoryB = NewTextRepository(transactor)
+			useCase     = usecase.NewUseCase(repositoryA, repositoryB, transactor)
 		)
 
 		err := useCase.CreateTextRecords(ctx, textRecord)
@@ -52,12 +60,15 @@ func Test_UseCase_CreateTextRecords(t *testing.T) {
 		})
 	})
 	t.Run("error_and_rollback", func(t *testing.T) {
+		leaks.Watch(t)
+
 		var (
 			ctx         = context.Background()
-			transactor  = opgx.NewTransactor(db)
-			repositoryA = NewTextRepository(transactor, false)
-			repositoryB = NewTextRepository(transactor, true)
-			useCase     = NewUseCase(repositoryA, repositoryB, transactor)
+			transactor  = NewConnTransactor(db)
+			repositoryA = NewTextRepository(transactor)
+			injector    = fault.NewInjector(fault.Rule{Point: fault.BeforeExec, Err: entity.ErrExpected})
+			repositoryB = NewTextRepository(fault.NewPgxRepoTransactor(transactor, injector))
+			useCase     = usecase.NewUseCase(repositoryA, repositoryB, transactor)
 		)
 
 		err := useCase.CreateTextRecords(ctx, textRecord)
@@ -79,9 +90,13 @@ func Test_UseCase_CreateTextRecords(t *testing.T) {
 }
 
 func Test_UseCases(t *testing.T) {
+	t.Parallel()
+
 	var (
 		globalCtx = context.Background()
-		db        = ConnectDB(globalCtx, t)
+		leaks     = testdb.NewLeakDetector(t)
+		schema    = testdb.NewSchema(t, leaks.ConnectionString())
+		db        = ConnectDB(globalCtx, t, schema.ConnectionString())
 	)
 
 	t.Cleanup(func() {
@@ -91,15 +106,17 @@ func Test_UseCases(t *testing.T) {
 
 	t.Run("single_repository", func(t *testing.T) {
 		t.Run("success_create", func(t *testing.T) {
+			leaks.Watch(t)
+
 			var (
 				ctx         = context.Background()
-				transactor  = opgx.NewTransactor(db)
-				repositoryA = NewTextRepository(transactor, false)
-				repositoryB = NewTextRepository(transactor, false)
-				useCases    = NewUseCases(
-					NewUseCase(repositoryA, repositoryB, transactor),
-					NewUseCase(repositoryA, repositoryB, transactor),
+				transactor  = NewConnTransactor(db)
+				repositoryA = NewTextRepository(transactor)
+				repositoryB = NewTextRepository(transactor)
+				useCases    = usecase.NewUseCases(
 					transactor,
+					usecase.NewStep("A", usecase.NewUseCase(repositoryA, repositoryB, transactor)),
+					usecase.NewStep("B", usecase.NewUseCase(repositoryA, repositoryB, transactor)),
 				)
 			)
 
@@ -121,15 +138,18 @@ func Test_UseCases(t *testing.T) {
 			})
 		})
 		t.Run("error_and_rollback", func(t *testing.T) {
+			leaks.Watch(t)
+
 			var (
 				ctx         = context.Background()
-				transactor  = opgx.NewTransactor(db)
-				repositoryA = NewTextRepository(transactor, false)
-				repositoryB = NewTextRepository(transactor, true)
-				useCases    = NewUseCases(
-					NewUseCase(repositoryA, repositoryB, transactor),
-					NewUseCase(repositoryA, repositoryB, transactor),
+				transactor  = NewConnTransactor(db)
+				repositoryA = NewTextRepository(transactor)
+				injector    = fault.NewInjector(fault.Rule{Point: fault.BeforeExec, Err: entity.ErrExpected})
+				repositoryB = NewTextRepository(fault.NewPgxRepoTransactor(transactor, injector))
+				useCases    = usecase.NewUseCases(
 					transactor,
+					usecase.NewStep("A", usecase.NewUseCase(repositoryA, repositoryB, transactor)),
+					usecase.NewStep("B", usecase.NewUseCase(repositoryA, repositoryB, transactor)),
 				)
 			)
 
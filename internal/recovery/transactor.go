@@ -0,0 +1,47 @@
+package recovery
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+)
+
+type (
+	transactor interface {
+		WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error)
+	}
+)
+
+// Transactor decorates a transactor and turns panics within WithinTx's function into [entity.PanicError].
+//
+// The decorated transactor receives an error instead of a panic and rolls the transaction back,
+// the returned error keeps the stack of the panicking goroutine.
+//
+// Only panics at the level wrapped by Transactor are typed: a nested WithinTx of the decorated transactor
+// turns a panic into a plain error before it reaches Transactor, so the nested levels have to use Transactor too.
+type Transactor struct {
+	transactor transactor
+}
+
+// NewTransactor returns new Transactor.
+func NewTransactor(transactor transactor) *Transactor {
+	return &Transactor{
+		transactor: transactor,
+	}
+}
+
+// WithinTx calls WithinTx of the decorated transactor with the recovering function.
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	return t.transactor.WithinTx(ctx, func(ctx context.Context) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = &entity.PanicError{
+					Value: p,
+					Stack: debug.Stack(),
+				}
+			}
+		}()
+		return fn(ctx)
+	})
+}
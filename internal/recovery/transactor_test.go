@@ -0,0 +1,63 @@
+package recovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+)
+
+const (
+	panicValue = "some panic"
+)
+
+type transactorFunc func(ctx context.Context, fn func(ctx context.Context) error) error
+
+func (f transactorFunc) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return f(ctx, fn)
+}
+
+func Test_Transactor(t *testing.T) {
+	t.Run("panic_to_error", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			rolledBack = false
+			transactor = NewTransactor(transactorFunc(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				err := fn(ctx)
+				rolledBack = err != nil
+				return err
+			}))
+		)
+
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			panic(panicValue)
+		})
+		assert.True(t, rolledBack)
+		assert.ErrorIs(t, err, entity.ErrPanicInTx)
+
+		var panicErr *entity.PanicError
+		if assert.True(t, errors.As(err, &panicErr)) {
+			assert.Equal(t, panicValue, panicErr.Value)
+			assert.Contains(t, string(panicErr.Stack), "recovery.Test_Transactor")
+		}
+	})
+	t.Run("error_as_is", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			expErr     = fmt.Errorf("some_error")
+			transactor = NewTransactor(transactorFunc(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			}))
+		)
+
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			return expErr
+		})
+		assert.ErrorIs(t, err, expErr)
+		assert.NotErrorIs(t, err, entity.ErrPanicInTx)
+	})
+}
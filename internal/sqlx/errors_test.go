@@ -0,0 +1,68 @@
+package sqlx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kozmod/oniontx"
+	osqlx "github.com/kozmod/oniontx/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/testdb"
+)
+
+func Test_TextRepository_Errors(t *testing.T) {
+	t.Parallel()
+
+	var (
+		globalCtx = context.Background()
+		leaks     = testdb.NewLeakDetector(t)
+		schema    = testdb.NewSchema(t, leaks.ConnectionString())
+		db        = ConnectDB(globalCtx, t, schema.ConnectionString())
+	)
+
+	t.Cleanup(func() {
+		err := db.Close()
+		assert.NoError(t, err)
+	})
+
+	for _, violation := range testdb.Violations() {
+		t.Run(violation.Name, func(t *testing.T) {
+			leaks.Watch(t)
+
+			var (
+				ctx        = globalCtx
+				transactor = osqlx.NewTransactor(db)
+				repository = NewTextRepository(transactor)
+			)
+
+			err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+				ex := transactor.GetExecutor(ctx)
+				for _, stmt := range violation.Setup {
+					_, err := ex.ExecContext(ctx, stmt)
+					assert.NoError(t, err)
+				}
+				for _, val := range violation.Values {
+					err := repository.Insert(ctx, val)
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			assert.ErrorIs(t, err, violation.Err)
+			assert.ErrorIs(t, err, oniontx.ErrRollbackSuccess)
+
+			{
+				records, err := GetTextRecords(globalCtx, db)
+				assert.NoError(t, err)
+				assert.Len(t, records, 0)
+			}
+
+			t.Cleanup(func() {
+				err = ClearDB(globalCtx, db)
+				assert.NoError(t, err)
+			})
+		})
+	}
+}
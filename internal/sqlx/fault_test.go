@@ -0,0 +1,127 @@
+package sqlx
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/kozmod/oniontx"
+	osqlx "github.com/kozmod/oniontx/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+	"github.com/kozmod/oniontx-examples/internal/fault"
+	"github.com/kozmod/oniontx-examples/internal/testdb"
+	"github.com/kozmod/oniontx-examples/internal/usecase"
+)
+
+func Test_UseCase_Faults(t *testing.T) {
+	t.Parallel()
+
+	var (
+		globalCtx = context.Background()
+		leaks     = testdb.NewLeakDetector(t)
+		schema    = testdb.NewSchema(t, leaks.ConnectionString())
+		db        = ConnectDB(globalCtx, t, schema.ConnectionString())
+	)
+
+	t.Cleanup(func() {
+		err := db.Close()
+		assert.NoError(t, err)
+	})
+
+	testCases := []struct {
+		name    string
+		timeout time.Duration
+		rules   []fault.Rule
+		expErrs []error
+	}{
+		{
+			name:    "nth_call",
+			timeout: time.Minute,
+			rules:   []fault.Rule{{Point: fault.BeforeExec, Nth: 2, Err: entity.ErrExpected}},
+			expErrs: []error{entity.ErrExpected, oniontx.ErrRollbackSuccess},
+		},
+		{
+			name:    "after_exec",
+			timeout: time.Minute,
+			rules:   []fault.Rule{{Point: fault.AfterExec, Nth: 2, Err: entity.ErrExpected}},
+			expErrs: []error{entity.ErrExpected, oniontx.ErrRollbackSuccess},
+		},
+		{
+			name:    "commit",
+			timeout: time.Minute,
+			rules:   []fault.Rule{{Point: fault.Commit, Err: entity.ErrExpected}},
+			expErrs: []error{entity.ErrExpected, oniontx.ErrCommitFailed},
+		},
+		{
+			name:    "rollback",
+			timeout: time.Minute,
+			rules: []fault.Rule{
+				{Point: fault.AfterExec, Nth: 1, Err: entity.ErrExpected},
+				{Point: fault.Rollback, Err: entity.ErrExpected},
+			},
+			expErrs: []error{entity.ErrExpected, oniontx.ErrRollbackFailed},
+		},
+		{
+			name:    "query",
+			timeout: time.Minute,
+			rules: []fault.Rule{{
+				Point: fault.BeforeExec,
+				Query: regexp.MustCompile(`^INSERT INTO text`),
+				Nth:   3,
+				Err:   entity.ErrExpected,
+			}},
+			expErrs: []error{entity.ErrExpected, oniontx.ErrRollbackSuccess},
+		},
+		{
+			name:    "drop_conn",
+			timeout: time.Minute,
+			rules:   []fault.Rule{{Point: fault.BeforeExec, Nth: 3, DropConn: true}},
+		},
+		{
+			name:    "latency",
+			timeout: 100 * time.Millisecond,
+			rules:   []fault.Rule{{Point: fault.BeforeExec, Nth: 2, Latency: time.Minute}},
+			expErrs: []error{context.DeadlineExceeded},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			leaks.Watch(t)
+
+			var (
+				ctx, cancel    = context.WithTimeout(globalCtx, tc.timeout)
+				injector       = fault.NewInjector(tc.rules...)
+				transactor     = osqlx.NewTransactor(db)
+				repoTransactor = fault.NewSqlxRepoTransactor(transactor, injector)
+				repository     = NewTextRepository(repoTransactor)
+				useCases       = usecase.NewUseCases(
+					fault.NewTransactor(transactor, injector),
+					usecase.NewStep("A", usecase.NewUseCase(repository, repository, transactor)),
+					usecase.NewStep("B", usecase.NewUseCase(repository, repository, transactor)),
+				)
+			)
+			defer cancel()
+
+			err := useCases.CreateTextRecords(ctx, textRecord)
+			assert.Error(t, err)
+			for _, expErr := range tc.expErrs {
+				assert.ErrorIs(t, err, expErr)
+			}
+
+			{
+				records, err := GetTextRecords(globalCtx, db)
+				assert.NoError(t, err)
+				assert.Len(t, records, 0)
+			}
+
+			t.Cleanup(func() {
+				err = ClearDB(globalCtx, db)
+				assert.NoError(t, err)
+			})
+		})
+	}
+}
@@ -2,17 +2,21 @@ package sqlx
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jmoiron/sqlx"
+	"github.com/kozmod/oniontx"
+	osqlx "github.com/kozmod/oniontx/sqlx"
 	"github.com/stretchr/testify/assert"
 
-	"github.com/kozmod/oniontx-examples/internal/entity"
+	"github.com/kozmod/oniontx-examples/internal/testdb"
 )
 
-func ConnectDB(ctx context.Context, t *testing.T) *sqlx.DB {
-	db, err := sqlx.Connect("postgres", entity.ConnectionString)
+func ConnectDB(ctx context.Context, t *testing.T, connString string) *sqlx.DB {
+	db, err := sqlx.Connect("postgres", connString)
 	assert.NoError(t, err)
 
 	err = db.Ping()
@@ -20,6 +24,49 @@ func ConnectDB(ctx context.Context, t *testing.T) *sqlx.DB {
 	return db
 }
 
+// NewSqlMock returns [sqlx.DB] backed by [sqlmock.Sqlmock], which matches the expected SQL exactly and in order.
+func NewSqlMock(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+		sqlMock.ExpectClose()
+		assert.NoError(t, db.Close())
+	})
+	return sqlx.NewDb(db, "sqlmock"), sqlMock
+}
+
+// RollbackTransactor manages transactions as savepoints of the test's outer [sqlx.Tx].
+type RollbackTransactor struct {
+	*oniontx.Transactor[*testdb.Savepoints[*sqlx.Tx], *testdb.Savepoint[*sqlx.Tx], *sql.TxOptions]
+}
+
+// NewRollbackTransactor begins the test's outer transaction and returns RollbackTransactor bound to it.
+// The outer transaction is rolled back in the test's cleanup, so the test leaves no records.
+func NewRollbackTransactor(ctx context.Context, t *testing.T, db *sqlx.DB) *RollbackTransactor {
+	tx, err := db.BeginTxx(ctx, nil)
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, tx.Rollback())
+	})
+
+	var (
+		base       = testdb.NewSavepoints(tx)
+		operator   = oniontx.NewContextOperator[*testdb.Savepoints[*sqlx.Tx], *testdb.Savepoint[*sqlx.Tx]](base)
+		transactor = oniontx.NewTransactor[*testdb.Savepoints[*sqlx.Tx], *testdb.Savepoint[*sqlx.Tx], *sql.TxOptions](base, operator)
+	)
+	return &RollbackTransactor{
+		Transactor: transactor,
+	}
+}
+
+// GetExecutor returns the test's outer [sqlx.Tx], which is the executor of the savepoints too.
+func (t *RollbackTransactor) GetExecutor(_ context.Context) osqlx.Executor {
+	return t.Transactor.TxBeginner().Tx()
+}
+
 func ClearDB(ctx context.Context, db *sqlx.DB) error {
 	_, err := db.ExecContext(ctx, `TRUNCATE TABLE text;`)
 	if err != nil {
@@ -28,7 +75,7 @@ func ClearDB(ctx context.Context, db *sqlx.DB) error {
 	return nil
 }
 
-func GetTextRecords(ctx context.Context, db *sqlx.DB) ([]string, error) {
+func GetTextRecords(ctx context.Context, db osqlx.Executor) ([]string, error) {
 	row, err := db.QueryContext(ctx, "SELECT val FROM text;")
 	if err != nil {
 		return nil, fmt.Errorf("get `text` records: %w", err)
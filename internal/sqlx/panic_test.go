@@ -0,0 +1,96 @@
+package sqlx
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kozmod/oniontx"
+	osqlx "github.com/kozmod/oniontx/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+	"github.com/kozmod/oniontx-examples/internal/recovery"
+	"github.com/kozmod/oniontx-examples/internal/testdb"
+	"github.com/kozmod/oniontx-examples/internal/usecase"
+)
+
+func Test_UseCase_Panic(t *testing.T) {
+	t.Parallel()
+
+	var (
+		globalCtx = context.Background()
+		leaks     = testdb.NewLeakDetector(t)
+		schema    = testdb.NewSchema(t, leaks.ConnectionString())
+		db        = ConnectDB(globalCtx, t, schema.ConnectionString())
+	)
+
+	t.Cleanup(func() {
+		err := db.Close()
+		assert.NoError(t, err)
+	})
+
+	t.Run("panic_and_rollback", func(t *testing.T) {
+		leaks.Watch(t)
+
+		var (
+			ctx         = context.Background()
+			transactor  = osqlx.NewTransactor(db)
+			repositoryA = NewTextRepository(transactor)
+			useCase     = usecase.NewUseCase(repositoryA, testdb.PanicTextRepository{}, transactor)
+		)
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, oniontx.ErrRollbackSuccess)
+		assert.ErrorContains(t, err, testdb.PanicValue)
+
+		{
+			records, err := GetTextRecords(ctx, db)
+			assert.NoError(t, err)
+			assert.Len(t, records, 0)
+			assert.Zero(t, db.Stats().InUse)
+		}
+
+		t.Cleanup(func() {
+			err = ClearDB(ctx, db)
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("recovered_panic_and_rollback", func(t *testing.T) {
+		leaks.Watch(t)
+
+		var (
+			ctx         = context.Background()
+			transactor  = osqlx.NewTransactor(db)
+			recovered   = recovery.NewTransactor(transactor)
+			repositoryA = NewTextRepository(transactor)
+			useCases    = usecase.NewUseCases(
+				recovered,
+				usecase.NewStep("A", usecase.NewUseCase(repositoryA, repositoryA, recovered)),
+				usecase.NewStep("B", usecase.NewUseCase(repositoryA, testdb.PanicTextRepository{}, recovered)),
+			)
+		)
+
+		err := useCases.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrPanicInTx)
+		assert.ErrorIs(t, err, oniontx.ErrRollbackSuccess)
+
+		var panicErr *entity.PanicError
+		if assert.True(t, errors.As(err, &panicErr)) {
+			assert.Equal(t, testdb.PanicValue, panicErr.Value)
+			assert.Contains(t, string(panicErr.Stack), "PanicTextRepository.Insert")
+		}
+
+		{
+			records, err := GetTextRecords(ctx, db)
+			assert.NoError(t, err)
+			assert.Len(t, records, 0)
+			assert.Zero(t, db.Stats().InUse)
+		}
+
+		t.Cleanup(func() {
+			err = ClearDB(ctx, db)
+			assert.NoError(t, err)
+		})
+	})
+}
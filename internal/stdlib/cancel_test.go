@@ -0,0 +1,100 @@
+package stdlib
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ostdlib "github.com/kozmod/oniontx/stdlib"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/testdb"
+	"github.com/kozmod/oniontx-examples/internal/usecase"
+)
+
+func Test_UseCase_Cancel(t *testing.T) {
+	t.Parallel()
+
+	var (
+		leaks  = testdb.NewLeakDetector(t)
+		schema = testdb.NewSchema(t, leaks.ConnectionString())
+		db     = ConnectDB(t, schema.ConnectionString())
+	)
+
+	t.Cleanup(func() {
+		err := db.Close()
+		assert.NoError(t, err)
+	})
+
+	assertUsable := func(t *testing.T) {
+		assert.Eventually(t, func() bool {
+			return db.Stats().InUse == 0
+		}, time.Second, 10*time.Millisecond)
+		assert.NoError(t, db.PingContext(context.Background()))
+	}
+
+	t.Run("cancel_between_inserts", func(t *testing.T) {
+		leaks.Watch(t)
+
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			transactor  = ostdlib.NewTransactor(db)
+			repositoryA = NewTextRepository(transactor)
+			repositoryB = testdb.InterruptTextRepository{
+				Repository: repositoryA,
+				Interrupt:  func(context.Context) { cancel() },
+			}
+			useCase = usecase.NewUseCase(repositoryA, repositoryB, transactor)
+		)
+		defer cancel()
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		{
+			records, err := GetTextRecords(db)
+			assert.NoError(t, err)
+			assert.Len(t, records, 0)
+			assertUsable(t)
+		}
+
+		t.Cleanup(func() {
+			err = ClearDB(db)
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("deadline_between_inserts", func(t *testing.T) {
+		leaks.Watch(t)
+
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+			transactor  = ostdlib.NewTransactor(db)
+			repositoryA = NewTextRepository(transactor)
+			repositoryB = testdb.InterruptTextRepository{
+				Repository: repositoryA,
+				Interrupt:  func(ctx context.Context) { <-ctx.Done() },
+			}
+			useCases = usecase.NewUseCases(
+				transactor,
+				usecase.NewStep("A", usecase.NewUseCase(repositoryA, repositoryB, transactor)),
+				usecase.NewStep("B", usecase.NewUseCase(repositoryA, repositoryA, transactor)),
+			)
+		)
+		defer cancel()
+
+		err := useCases.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		{
+			records, err := GetTextRecords(db)
+			assert.NoError(t, err)
+			assert.Len(t, records, 0)
+			assertUsable(t)
+		}
+
+		t.Cleanup(func() {
+			err = ClearDB(db)
+			assert.NoError(t, err)
+		})
+	})
+}
@@ -1,19 +1,23 @@
 package stdlib
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/kozmod/oniontx"
+	ostdlib "github.com/kozmod/oniontx/stdlib"
 	"github.com/stretchr/testify/assert"
 
-	"github.com/kozmod/oniontx-examples/internal/entity"
+	"github.com/kozmod/oniontx-examples/internal/testdb"
 )
 
-func ConnectDB(t *testing.T) *sql.DB {
-	connConfig, err := pgx.ParseConfig(entity.ConnectionString)
+func ConnectDB(t *testing.T, connString string) *sql.DB {
+	connConfig, err := pgx.ParseConfig(connString)
 	assert.NoError(t, err)
 
 	connStr := stdlib.RegisterConnConfig(connConfig)
@@ -26,6 +30,49 @@ func ConnectDB(t *testing.T) *sql.DB {
 	return db
 }
 
+// NewSqlMock returns [sql.DB] backed by [sqlmock.Sqlmock], which matches the expected SQL exactly and in order.
+func NewSqlMock(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+		sqlMock.ExpectClose()
+		assert.NoError(t, db.Close())
+	})
+	return db, sqlMock
+}
+
+// RollbackTransactor manages transactions as savepoints of the test's outer [sql.Tx].
+type RollbackTransactor struct {
+	*oniontx.Transactor[*testdb.Savepoints[*sql.Tx], *testdb.Savepoint[*sql.Tx], *sql.TxOptions]
+}
+
+// NewRollbackTransactor begins the test's outer transaction and returns RollbackTransactor bound to it.
+// The outer transaction is rolled back in the test's cleanup, so the test leaves no records.
+func NewRollbackTransactor(ctx context.Context, t *testing.T, db *sql.DB) *RollbackTransactor {
+	tx, err := db.BeginTx(ctx, nil)
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, tx.Rollback())
+	})
+
+	var (
+		base       = testdb.NewSavepoints(tx)
+		operator   = oniontx.NewContextOperator[*testdb.Savepoints[*sql.Tx], *testdb.Savepoint[*sql.Tx]](base)
+		transactor = oniontx.NewTransactor[*testdb.Savepoints[*sql.Tx], *testdb.Savepoint[*sql.Tx], *sql.TxOptions](base, operator)
+	)
+	return &RollbackTransactor{
+		Transactor: transactor,
+	}
+}
+
+// GetExecutor returns the test's outer [sql.Tx], which is the executor of the savepoints too.
+func (t *RollbackTransactor) GetExecutor(_ context.Context) ostdlib.Executor {
+	return t.Transactor.TxBeginner().Tx()
+}
+
 func ClearDB(db *sql.DB) error {
 	_, err := db.Exec("TRUNCATE TABLE text;")
 	if err != nil {
@@ -34,7 +81,7 @@ func ClearDB(db *sql.DB) error {
 	return nil
 }
 
-func GetTextRecords(db *sql.DB) ([]string, error) {
+func GetTextRecords(db ostdlib.Executor) ([]string, error) {
 	row, err := db.Query("SELECT val FROM text;")
 	if err != nil {
 		return nil, fmt.Errorf("get `text` records: %w", err)
@@ -16,25 +16,20 @@ type (
 )
 
 type TextRepository struct {
-	transactor    repoTransactor
-	errorExpected bool
+	transactor repoTransactor
 }
 
-func NewTextRepository(transactor repoTransactor, errorExpected bool) *TextRepository {
+func NewTextRepository(transactor repoTransactor) *TextRepository {
 	return &TextRepository{
-		transactor:    transactor,
-		errorExpected: errorExpected,
+		transactor: transactor,
 	}
 }
 
 func (r *TextRepository) Insert(ctx context.Context, val string) error {
-	if r.errorExpected {
-		return entity.ErrExpected
-	}
 	ex := r.transactor.GetExecutor(ctx)
 	_, err := ex.ExecContext(ctx, `INSERT INTO text (val) VALUES ($1)`, val)
 	if err != nil {
-		return fmt.Errorf("stdlib repository: %w", err)
+		return fmt.Errorf("stdlib repository: %w", entity.MapError(err))
 	}
 	return nil
 }
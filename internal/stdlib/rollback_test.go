@@ -0,0 +1,109 @@
+package stdlib
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+	"github.com/kozmod/oniontx-examples/internal/fault"
+	"github.com/kozmod/oniontx-examples/internal/testdb"
+	"github.com/kozmod/oniontx-examples/internal/usecase"
+)
+
+func Test_UseCase_Rollback(t *testing.T) {
+	t.Parallel()
+
+	var (
+		leaks  = testdb.NewLeakDetector(t)
+		schema = testdb.NewSchema(t, leaks.ConnectionString())
+		db     = ConnectDB(t, schema.ConnectionString())
+	)
+
+	t.Cleanup(func() {
+		err := db.Close()
+		assert.NoError(t, err)
+	})
+
+	t.Run("success_create", func(t *testing.T) {
+		leaks.Watch(t)
+
+		var (
+			ctx         = context.Background()
+			transactor  = NewRollbackTransactor(ctx, t, db)
+			repositoryA = NewTextRepository(transactor)
+			repositoryB = NewTextRepository(transactor)
+			useCase     = usecase.NewUseCase(repositoryA, repositoryB, transactor)
+		)
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+
+		records, err := GetTextRecords(transactor.GetExecutor(ctx))
+		assert.NoError(t, err)
+		assert.Len(t, records, 2)
+	})
+	t.Run("error_and_rollback_to_savepoint", func(t *testing.T) {
+		leaks.Watch(t)
+
+		var (
+			ctx         = context.Background()
+			transactor  = NewRollbackTransactor(ctx, t, db)
+			repositoryA = NewTextRepository(transactor)
+			injector    = fault.NewInjector(fault.Rule{Point: fault.BeforeExec, Err: entity.ErrExpected})
+			repositoryB = NewTextRepository(fault.NewStdlibRepoTransactor(transactor, injector))
+			useCase     = usecase.NewUseCase(repositoryA, repositoryB, transactor)
+		)
+
+		err := repositoryA.Insert(ctx, textRecord)
+		assert.NoError(t, err)
+
+		err = useCase.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+
+		records, err := GetTextRecords(transactor.GetExecutor(ctx))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{textRecord}, records)
+	})
+	t.Run("nested_use_cases", func(t *testing.T) {
+		leaks.Watch(t)
+
+		var (
+			ctx         = context.Background()
+			transactor  = NewRollbackTransactor(ctx, t, db)
+			repositoryA = NewTextRepository(transactor)
+			repositoryB = NewTextRepository(transactor)
+			useCases    = usecase.NewUseCases(
+				transactor,
+				usecase.NewStep("A", usecase.NewUseCase(repositoryA, repositoryB, transactor)),
+				usecase.NewStep("B", usecase.NewUseCase(repositoryA, repositoryB, transactor)),
+			)
+		)
+
+		err := useCases.CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+
+		records, err := GetTextRecords(transactor.GetExecutor(ctx))
+		assert.NoError(t, err)
+		assert.Len(t, records, 4)
+	})
+	t.Run("rolled_back_after_test", func(t *testing.T) {
+		t.Run("create", func(t *testing.T) {
+			var (
+				ctx         = context.Background()
+				transactor  = NewRollbackTransactor(ctx, t, db)
+				repositoryA = NewTextRepository(transactor)
+				repositoryB = NewTextRepository(transactor)
+				useCase     = usecase.NewUseCase(repositoryA, repositoryB, transactor)
+			)
+
+			err := useCase.CreateTextRecords(ctx, textRecord)
+			assert.NoError(t, err)
+		})
+
+		records, err := GetTextRecords(db)
+		assert.NoError(t, err)
+		assert.Len(t, records, 0)
+	})
+}
@@ -0,0 +1,198 @@
+package stdlib
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kozmod/oniontx"
+	ostdlib "github.com/kozmod/oniontx/stdlib"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+	"github.com/kozmod/oniontx-examples/internal/usecase"
+)
+
+const (
+	insertQuery = `INSERT INTO text (val) VALUES ($1)`
+)
+
+func Test_UseCase_SqlMock(t *testing.T) {
+	t.Run("success_create", func(t *testing.T) {
+		var (
+			ctx         = context.Background()
+			db, sqlMock = NewSqlMock(t)
+			transactor  = ostdlib.NewTransactor(db)
+			repository  = NewTextRepository(transactor)
+			useCase     = usecase.NewUseCase(repository, repository, transactor)
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectCommit()
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+	})
+	t.Run("error_and_rollback", func(t *testing.T) {
+		var (
+			ctx         = context.Background()
+			db, sqlMock = NewSqlMock(t)
+			transactor  = ostdlib.NewTransactor(db)
+			repository  = NewTextRepository(transactor)
+			useCase     = usecase.NewUseCase(repository, repository, transactor)
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnError(entity.ErrExpected)
+		sqlMock.ExpectRollback()
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.ErrorIs(t, err, oniontx.ErrRollbackSuccess)
+	})
+	t.Run("commit_error", func(t *testing.T) {
+		var (
+			ctx         = context.Background()
+			db, sqlMock = NewSqlMock(t)
+			transactor  = ostdlib.NewTransactor(db)
+			repository  = NewTextRepository(transactor)
+			useCase     = usecase.NewUseCase(repository, repository, transactor)
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectCommit().WillReturnError(entity.ErrExpected)
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.ErrorIs(t, err, oniontx.ErrCommitFailed)
+	})
+	t.Run("rollback_error", func(t *testing.T) {
+		var (
+			ctx         = context.Background()
+			db, sqlMock = NewSqlMock(t)
+			transactor  = ostdlib.NewTransactor(db)
+			repository  = NewTextRepository(transactor)
+			useCase     = usecase.NewUseCase(repository, repository, transactor)
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnError(entity.ErrExpected)
+		sqlMock.ExpectRollback().WillReturnError(sql.ErrConnDone)
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+		assert.ErrorIs(t, err, oniontx.ErrRollbackFailed)
+	})
+}
+
+func Test_UseCases_SqlMock(t *testing.T) {
+	t.Run("success_create", func(t *testing.T) {
+		var (
+			ctx         = context.Background()
+			db, sqlMock = NewSqlMock(t)
+			transactor  = ostdlib.NewTransactor(db)
+			repository  = NewTextRepository(transactor)
+			useCases    = usecase.NewUseCases(
+				transactor,
+				usecase.NewStep("A", usecase.NewUseCase(repository, repository, transactor)),
+				usecase.NewStep("B", usecase.NewUseCase(repository, repository, transactor)),
+			)
+		)
+
+		// only the root transaction begins and commits.
+		sqlMock.ExpectBegin()
+		for i := 0; i < 4; i++ {
+			sqlMock.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		sqlMock.ExpectCommit()
+
+		err := useCases.CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+	})
+	t.Run("error_and_rollback", func(t *testing.T) {
+		var (
+			ctx         = context.Background()
+			db, sqlMock = NewSqlMock(t)
+			transactor  = ostdlib.NewTransactor(db)
+			repository  = NewTextRepository(transactor)
+			useCases    = usecase.NewUseCases(
+				transactor,
+				usecase.NewStep("A", usecase.NewUseCase(repository, repository, transactor)),
+				usecase.NewStep("B", usecase.NewUseCase(repository, repository, transactor)),
+			)
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnError(entity.ErrExpected)
+		sqlMock.ExpectRollback()
+
+		err := useCases.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.ErrorContains(t, err, "text usecase B")
+		assert.ErrorIs(t, err, oniontx.ErrRollbackSuccess)
+	})
+}
+
+func Test_RollbackTransactor_SqlMock(t *testing.T) {
+	t.Run("release_savepoint", func(t *testing.T) {
+		var (
+			ctx         = context.Background()
+			db, sqlMock = NewSqlMock(t)
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectExec(`SAVEPOINT sp_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+		sqlMock.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(`RELEASE SAVEPOINT sp_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+		sqlMock.ExpectRollback()
+
+		var (
+			transactor = NewRollbackTransactor(ctx, t, db)
+			repository = NewTextRepository(transactor)
+			useCase    = usecase.NewUseCase(repository, repository, transactor)
+		)
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+	})
+	t.Run("rollback_to_savepoint", func(t *testing.T) {
+		var (
+			ctx         = context.Background()
+			db, sqlMock = NewSqlMock(t)
+		)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectExec(`SAVEPOINT sp_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+		sqlMock.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(`RELEASE SAVEPOINT sp_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+		sqlMock.ExpectExec(`SAVEPOINT sp_2`).WillReturnResult(sqlmock.NewResult(0, 0))
+		sqlMock.ExpectExec(insertQuery).WithArgs(textRecord).WillReturnError(entity.ErrExpected)
+		sqlMock.ExpectExec(`ROLLBACK TO SAVEPOINT sp_2`).WillReturnResult(sqlmock.NewResult(0, 0))
+		sqlMock.ExpectRollback()
+
+		var (
+			transactor = NewRollbackTransactor(ctx, t, db)
+			repository = NewTextRepository(transactor)
+			insert     = func(ctx context.Context) error {
+				return repository.Insert(ctx, textRecord)
+			}
+		)
+
+		err := transactor.WithinTx(ctx, insert)
+		assert.NoError(t, err)
+
+		err = transactor.WithinTx(ctx, insert)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.ErrorIs(t, err, oniontx.ErrRollbackSuccess)
+	})
+}
@@ -8,6 +8,9 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/kozmod/oniontx-examples/internal/entity"
+	"github.com/kozmod/oniontx-examples/internal/fault"
+	"github.com/kozmod/oniontx-examples/internal/testdb"
+	"github.com/kozmod/oniontx-examples/internal/usecase"
 )
 
 const (
@@ -15,8 +18,12 @@ const (
 )
 
 func Test_UseCase(t *testing.T) {
+	t.Parallel()
+
 	var (
-		db = ConnectDB(t)
+		leaks  = testdb.NewLeakDetector(t)
+		schema = testdb.NewSchema(t, leaks.ConnectionString())
+		db     = ConnectDB(t, schema.ConnectionString())
 	)
 
 	t.Cleanup(func() {
@@ -25,12 +32,14 @@ func Test_UseCase(t *testing.T) {
 	})
 
 	t.Run("success_create", func(t *testing.T) {
+		leaks.Watch(t)
+
 		var (
 			ctx         = context.Background()
 			transactor  = ostdlib.NewTransactor(db)
-			repositoryA = NewTextRepository(transactor, false)
-			repositoryB = NewTextRepository(transactor, false)
-			useCase     = NewUseCase(repositoryA, repositoryB, transactor)
+			repositoryA = NewTextRepository(transactor)
+			repositoryB = NewTextRepository(transactor)
+			useCase     = usecase.NewUseCase(repositoryA, repositoryB, transactor)
 		)
 
 		err := useCase.CreateTextRecords(ctx, textRecord)
@@ -51,12 +60,15 @@ func Test_UseCase(t *testing.T) {
 		})
 	})
 	t.Run("error_and_rollback", func(t *testing.T) {
+		leaks.Watch(t)
+
 		var (
 			ctx         = context.Background()
 			transactor  = ostdlib.NewTransactor(db)
-			repositoryA = NewTextRepository(transactor, false)
-			repositoryB = NewTextRepository(transactor, true)
-			useCase     = NewUseCase(repositoryA, repositoryB, transactor)
+			repositoryA = NewTextRepository(transactor)
+			injector    = fault.NewInjector(fault.Rule{Point: fault.BeforeExec, Err: entity.ErrExpected})
+			repositoryB = NewTextRepository(fault.NewStdlibRepoTransactor(transactor, injector))
+			useCase     = usecase.NewUseCase(repositoryA, repositoryB, transactor)
 		)
 
 		err := useCase.CreateTextRecords(ctx, textRecord)
@@ -77,8 +89,12 @@ func Test_UseCase(t *testing.T) {
 }
 
 func Test_UseCases(t *testing.T) {
+	t.Parallel()
+
 	var (
-		db = ConnectDB(t)
+		leaks  = testdb.NewLeakDetector(t)
+		schema = testdb.NewSchema(t, leaks.ConnectionString())
+		db     = ConnectDB(t, schema.ConnectionString())
 	)
 
 	t.Cleanup(func() {
@@ -88,15 +104,17 @@ func Test_UseCases(t *testing.T) {
 
 	t.Run("single_repository", func(t *testing.T) {
 		t.Run("success_create", func(t *testing.T) {
+			leaks.Watch(t)
+
 			var (
 				ctx         = context.Background()
 				transactor  = ostdlib.NewTransactor(db)
-				repositoryA = NewTextRepository(transactor, false)
-				repositoryB = NewTextRepository(transactor, false)
-				useCases    = NewUseCases(
-					NewUseCase(repositoryA, repositoryB, transactor),
-					NewUseCase(repositoryA, repositoryB, transactor),
+				repositoryA = NewTextRepository(transactor)
+				repositoryB = NewTextRepository(transactor)
+				useCases    = usecase.NewUseCases(
 					transactor,
+					usecase.NewStep("A", usecase.NewUseCase(repositoryA, repositoryB, transactor)),
+					usecase.NewStep("B", usecase.NewUseCase(repositoryA, repositoryB, transactor)),
 				)
 			)
 
@@ -118,15 +136,18 @@ func Test_UseCases(t *testing.T) {
 			})
 		})
 		t.Run("error_and_rollback", func(t *testing.T) {
+			leaks.Watch(t)
+
 			var (
 				ctx         = context.Background()
 				transactor  = ostdlib.NewTransactor(db)
-				repositoryA = NewTextRepository(transactor, false)
-				repositoryB = NewTextRepository(transactor, true)
-				useCases    = NewUseCases(
-					NewUseCase(repositoryA, repositoryB, transactor),
-					NewUseCase(repositoryA, repositoryB, transactor),
+				repositoryA = NewTextRepository(transactor)
+				injector    = fault.NewInjector(fault.Rule{Point: fault.BeforeExec, Err: entity.ErrExpected})
+				repositoryB = NewTextRepository(fault.NewStdlibRepoTransactor(transactor, injector))
+				useCases    = usecase.NewUseCases(
 					transactor,
+					usecase.NewStep("A", usecase.NewUseCase(repositoryA, repositoryB, transactor)),
+					usecase.NewStep("B", usecase.NewUseCase(repositoryA, repositoryB, transactor)),
 				)
 			)
 
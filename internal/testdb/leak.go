@@ -0,0 +1,116 @@
+package testdb
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+)
+
+const (
+	applicationNamePrefix = "oniontx-test-"
+	applicationNameParam  = "application_name"
+)
+
+// LeakDetector checks that the test's connections are not left `idle in transaction`.
+//
+// The test's connections are marked by the unique `application_name` (look at [LeakDetector.ConnectionString]),
+// the check queries `pg_stat_activity` with a separate connection.
+type LeakDetector struct {
+	applicationName  string
+	connectionString string
+}
+
+// NewLeakDetector returns new LeakDetector.
+func NewLeakDetector(t testing.TB) *LeakDetector {
+	t.Helper()
+
+	suffix, err := randomHex(4)
+	assert.NoError(t, err)
+
+	applicationName := applicationNamePrefix + suffix
+	connectionString, err := withParam(entity.ConnectionString, applicationNameParam, applicationName)
+	assert.NoError(t, err)
+
+	return &LeakDetector{
+		applicationName:  applicationName,
+		connectionString: connectionString,
+	}
+}
+
+// ApplicationName returns the unique `application_name` of the test's connections.
+func (d *LeakDetector) ApplicationName() string {
+	return d.applicationName
+}
+
+// ConnectionString returns [entity.ConnectionString] with the unique `application_name`.
+func (d *LeakDetector) ConnectionString() string {
+	return d.connectionString
+}
+
+// IdleInTx returns the number of the test's connections which are `idle in transaction`.
+func (d *LeakDetector) IdleInTx(ctx context.Context) (int, error) {
+	conn, err := pgx.Connect(ctx, entity.ConnectionString)
+	if err != nil {
+		return 0, fmt.Errorf("leak detector - connect: %w", err)
+	}
+	defer func() {
+		_ = conn.Close(ctx)
+	}()
+
+	var count int
+	err = conn.QueryRow(ctx,
+		`SELECT count(*) FROM pg_stat_activity WHERE application_name = $1 AND state = 'idle in transaction';`,
+		d.applicationName,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("leak detector - get `idle in transaction` connections: %w", err)
+	}
+	return count, nil
+}
+
+// AssertNoIdleInTx asserts that none of the test's connections is `idle in transaction`.
+func (d *LeakDetector) AssertNoIdleInTx(t testing.TB) bool {
+	t.Helper()
+
+	count, err := d.IdleInTx(context.Background())
+	if !assert.NoError(t, err) {
+		return false
+	}
+	return assert.Zerof(t, count, "connections [%s] are left `idle in transaction`", d.applicationName)
+}
+
+// Watch registers [LeakDetector.AssertNoIdleInTx] as the test's cleanup function.
+func (d *LeakDetector) Watch(t testing.TB) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		d.AssertNoIdleInTx(t)
+	})
+}
+
+func withParam(connectionString, key, value string) (string, error) {
+	u, err := url.Parse(connectionString)
+	if err != nil {
+		return "", fmt.Errorf("parse connection string: %w", err)
+	}
+	query := u.Query()
+	query.Set(key, value)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("random: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
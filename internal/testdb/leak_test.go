@@ -0,0 +1,50 @@
+package testdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LeakDetector(t *testing.T) {
+	t.Run("detect_idle_in_tx", func(t *testing.T) {
+		var (
+			ctx   = context.Background()
+			leaks = NewLeakDetector(t)
+		)
+
+		conn, err := pgx.Connect(ctx, leaks.ConnectionString())
+		assert.NoError(t, err)
+		t.Cleanup(func() {
+			err = conn.Close(ctx)
+			assert.NoError(t, err)
+		})
+
+		count, err := leaks.IdleInTx(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, count)
+
+		tx, err := conn.Begin(ctx)
+		assert.NoError(t, err)
+
+		count, err = leaks.IdleInTx(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		err = tx.Rollback(ctx)
+		assert.NoError(t, err)
+
+		count, err = leaks.IdleInTx(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, count)
+	})
+	t.Run("connection_string", func(t *testing.T) {
+		leaks := NewLeakDetector(t)
+
+		config, err := pgx.ParseConfig(leaks.ConnectionString())
+		assert.NoError(t, err)
+		assert.Equal(t, leaks.ApplicationName(), config.RuntimeParams[applicationNameParam])
+	})
+}
@@ -0,0 +1,34 @@
+package testdb
+
+import (
+	"context"
+)
+
+const (
+	// PanicValue is the value which PanicTextRepository panics with.
+	PanicValue = "repository panic"
+)
+
+type textRepository interface {
+	Insert(ctx context.Context, val string) error
+}
+
+// PanicTextRepository panics on Insert.
+type PanicTextRepository struct{}
+
+// Insert panics with PanicValue.
+func (PanicTextRepository) Insert(context.Context, string) error {
+	panic(PanicValue)
+}
+
+// InterruptTextRepository calls Interrupt before delegating Insert to the wrapped Repository.
+type InterruptTextRepository struct {
+	Repository textRepository
+	Interrupt  func(ctx context.Context)
+}
+
+// Insert calls Interrupt and inserts the value by the wrapped Repository.
+func (r InterruptTextRepository) Insert(ctx context.Context, val string) error {
+	r.Interrupt(ctx)
+	return r.Repository.Insert(ctx, val)
+}
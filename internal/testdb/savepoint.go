@@ -0,0 +1,68 @@
+package testdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/kozmod/oniontx"
+)
+
+// savepointExecutor is the test's outer transaction, e.g. [sql.Tx] or [sqlx.Tx].
+type savepointExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Savepoints wraps the test's outer transaction and implements [oniontx.TxBeginner].
+// Each transaction begun by Savepoints is a savepoint of the outer transaction.
+type Savepoints[E savepointExecutor] struct {
+	tx  E
+	seq atomic.Uint64
+}
+
+// NewSavepoints returns new Savepoints of the test's outer transaction.
+func NewSavepoints[E savepointExecutor](tx E) *Savepoints[E] {
+	return &Savepoints[E]{
+		tx: tx,
+	}
+}
+
+// Tx returns the test's outer transaction, which is the executor of the savepoints too.
+func (s *Savepoints[E]) Tx() E {
+	return s.tx
+}
+
+// BeginTx creates a savepoint, [sql.TxOptions] are ignored.
+func (s *Savepoints[E]) BeginTx(ctx context.Context, _ ...oniontx.Option[*sql.TxOptions]) (*Savepoint[E], error) {
+	name := fmt.Sprintf("sp_%d", s.seq.Add(1))
+	_, err := s.tx.ExecContext(ctx, `SAVEPOINT `+name)
+	if err != nil {
+		return nil, fmt.Errorf("savepoint [%s]: %w", name, err)
+	}
+	return &Savepoint[E]{tx: s.tx, name: name}, nil
+}
+
+// Savepoint is the savepoint of the test's outer transaction and implements [oniontx.Tx].
+type Savepoint[E savepointExecutor] struct {
+	tx   E
+	name string
+}
+
+// Rollback rolls back to the savepoint.
+func (s *Savepoint[E]) Rollback(ctx context.Context) error {
+	_, err := s.tx.ExecContext(context.WithoutCancel(ctx), `ROLLBACK TO SAVEPOINT `+s.name)
+	if err != nil {
+		return fmt.Errorf("rollback to savepoint [%s]: %w", s.name, err)
+	}
+	return nil
+}
+
+// Commit releases the savepoint, the changes stay in the test's outer transaction.
+func (s *Savepoint[E]) Commit(ctx context.Context) error {
+	_, err := s.tx.ExecContext(ctx, `RELEASE SAVEPOINT `+s.name)
+	if err != nil {
+		return fmt.Errorf("release savepoint [%s]: %w", s.name, err)
+	}
+	return nil
+}
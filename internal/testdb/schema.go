@@ -0,0 +1,113 @@
+package testdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+	"github.com/kozmod/oniontx-examples/migration/migrations"
+)
+
+const (
+	schemaNamePrefix = "oniontx_test_"
+	searchPathParam  = "search_path"
+)
+
+// Schema is the test's own Postgres schema with the applied migrations.
+//
+// The test's connections use the schema by `search_path` (look at [Schema.ConnectionString]),
+// so tests with different schemas don't share the `text` table and can run in parallel.
+type Schema struct {
+	name             string
+	connectionString string
+}
+
+// NewSchema creates the unique schema, applies the migrations to it and drops the schema in the test's cleanup.
+//
+// connectionString is the base connection string of the test's connections (e.g. [LeakDetector.ConnectionString]).
+func NewSchema(t testing.TB, connectionString string) *Schema {
+	t.Helper()
+
+	suffix, err := randomHex(4)
+	assert.NoError(t, err)
+
+	name := schemaNamePrefix + suffix
+	connectionString, err = withParam(connectionString, searchPathParam, name)
+	assert.NoError(t, err)
+
+	schema := Schema{
+		name:             name,
+		connectionString: connectionString,
+	}
+
+	ctx := context.Background()
+	if err := schema.create(ctx); !assert.NoError(t, err) {
+		return &schema
+	}
+	t.Cleanup(func() {
+		assert.NoError(t, schema.drop(context.Background()))
+	})
+
+	assert.NoError(t, schema.migrate(ctx))
+	return &schema
+}
+
+// Name returns the name of the schema.
+func (s *Schema) Name() string {
+	return s.name
+}
+
+// ConnectionString returns the base connection string with the schema's `search_path`.
+func (s *Schema) ConnectionString() string {
+	return s.connectionString
+}
+
+func (s *Schema) create(ctx context.Context) error {
+	return s.exec(ctx, `CREATE SCHEMA `+pgx.Identifier{s.name}.Sanitize())
+}
+
+func (s *Schema) drop(ctx context.Context) error {
+	return s.exec(ctx, `DROP SCHEMA IF EXISTS `+pgx.Identifier{s.name}.Sanitize()+` CASCADE`)
+}
+
+func (s *Schema) exec(ctx context.Context, sql string) error {
+	conn, err := pgx.Connect(ctx, entity.ConnectionString)
+	if err != nil {
+		return fmt.Errorf("schema [%s] - connect: %w", s.name, err)
+	}
+	defer func() {
+		_ = conn.Close(ctx)
+	}()
+
+	if _, err = conn.Exec(ctx, sql); err != nil {
+		return fmt.Errorf("schema [%s] - exec: %w", s.name, err)
+	}
+	return nil
+}
+
+// migrate applies the migrations, the tables (including goose's version table) are created in the schema.
+func (s *Schema) migrate(ctx context.Context) error {
+	db, err := sql.Open("pgx", s.connectionString)
+	if err != nil {
+		return fmt.Errorf("schema [%s] - open: %w", s.name, err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	provider, err := goose.NewProvider(goose.DialectPostgres, db, migrations.FS)
+	if err != nil {
+		return fmt.Errorf("schema [%s] - migration provider: %w", s.name, err)
+	}
+	if _, err = provider.Up(ctx); err != nil {
+		return fmt.Errorf("schema [%s] - migrate: %w", s.name, err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package testdb
+
+import (
+	"github.com/kozmod/oniontx-examples/internal/entity"
+)
+
+// Violation describes a constraint of the `text` table which inserting of Values violates.
+//
+// Setup statements add the constraint and have to be executed within the test's transaction:
+// Postgres DDL is transactional, so the constraint disappears with the transaction's rollback.
+type Violation struct {
+	Name   string
+	Setup  []string
+	Values []string
+	Err    error
+}
+
+const (
+	lockText = `LOCK TABLE text IN ACCESS EXCLUSIVE MODE`
+
+	violationValue = "text_violation"
+)
+
+// Violations returns a Violation for each domain error of [entity.MapError].
+func Violations() []Violation {
+	return []Violation{
+		{
+			Name: "duplicate",
+			Setup: []string{
+				lockText,
+				`CREATE UNIQUE INDEX text_violation_unique_idx ON text (val) WHERE val = '` + violationValue + `'`,
+			},
+			Values: []string{violationValue, violationValue},
+			Err:    entity.ErrDuplicate,
+		},
+		{
+			Name: "foreign_key",
+			Setup: []string{
+				lockText,
+				`CREATE TABLE text_violation_parent (val text PRIMARY KEY)`,
+				`ALTER TABLE text ADD CONSTRAINT text_violation_fkey FOREIGN KEY (val) REFERENCES text_violation_parent (val) NOT VALID`,
+			},
+			Values: []string{violationValue},
+			Err:    entity.ErrForeignKey,
+		},
+		{
+			Name: "not_null",
+			Setup: []string{
+				lockText,
+				`CREATE FUNCTION text_violation_null() RETURNS trigger LANGUAGE plpgsql AS $$
+				BEGIN
+					NEW.val := NULL;
+					RETURN NEW;
+				END $$`,
+				`CREATE TRIGGER text_violation_null BEFORE INSERT ON text FOR EACH ROW EXECUTE FUNCTION text_violation_null()`,
+			},
+			Values: []string{violationValue},
+			Err:    entity.ErrNotNull,
+		},
+		{
+			Name: "check",
+			Setup: []string{
+				lockText,
+				`ALTER TABLE text ADD CONSTRAINT text_violation_check CHECK (val <> '') NOT VALID`,
+			},
+			Values: []string{""},
+			Err:    entity.ErrCheckViolation,
+		},
+		{
+			// the trigger simulates the serialization failure, which is not reproducible deterministically.
+			Name: "serialization",
+			Setup: []string{
+				lockText,
+				`CREATE FUNCTION text_violation_serialization() RETURNS trigger LANGUAGE plpgsql AS $$
+				BEGIN
+					RAISE EXCEPTION 'could not serialize access' USING ERRCODE = 'serialization_failure';
+				END $$`,
+				`CREATE TRIGGER text_violation_serialization BEFORE INSERT ON text FOR EACH ROW EXECUTE FUNCTION text_violation_serialization()`,
+			},
+			Values: []string{violationValue},
+			Err:    entity.ErrSerialization,
+		},
+	}
+}
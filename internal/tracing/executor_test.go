@@ -0,0 +1,263 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	ogorm "github.com/kozmod/oniontx/gorm"
+	opgx "github.com/kozmod/oniontx/pgx"
+	osqlx "github.com/kozmod/oniontx/sqlx"
+	ostdlib "github.com/kozmod/oniontx/stdlib"
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+	"github.com/kozmod/oniontx-examples/internal/fault"
+	gormexample "github.com/kozmod/oniontx-examples/internal/gorm"
+	pgxexample "github.com/kozmod/oniontx-examples/internal/pgx"
+	sqlxexample "github.com/kozmod/oniontx-examples/internal/sqlx"
+	stdlibexample "github.com/kozmod/oniontx-examples/internal/stdlib"
+	"github.com/kozmod/oniontx-examples/internal/testdb"
+	"github.com/kozmod/oniontx-examples/internal/usecase"
+)
+
+const (
+	textRecord = "text_A"
+)
+
+func Test_StdlibRepoTransactor(t *testing.T) {
+	t.Parallel()
+
+	var (
+		schema = testdb.NewSchema(t, entity.ConnectionString)
+		db     = ConnectDB(t, schema.ConnectionString())
+	)
+
+	t.Cleanup(func() {
+		err := db.Close()
+		assert.NoError(t, err)
+	})
+
+	newUseCase := func(provider *sdktrace.TracerProvider, errorExpected bool) *usecase.UseCase[string] {
+		var (
+			transactor     = ostdlib.NewTransactor(db)
+			repoTransactor = NewStdlibRepoTransactor(transactor, provider)
+			repositoryA    = stdlibexample.NewTextRepository(repoTransactor)
+			repositoryB    = stdlibexample.NewTextRepository(fault.NewStdlibRepoTransactor(repoTransactor, ExpectedErrorInjector(errorExpected)))
+		)
+		return usecase.NewUseCase(repositoryA, repositoryB, NewTransactor(transactor, provider))
+	}
+
+	t.Run("success_create", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			exporter = tracetest.NewInMemoryExporter()
+			provider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		)
+
+		err := newUseCase(provider, false).CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+		AssertTxSpans(t, exporter.GetSpans(), OutcomeCommitted, 2)
+
+		t.Cleanup(func() {
+			err = ClearDB(db)
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("error_and_rollback", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			exporter = tracetest.NewInMemoryExporter()
+			provider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		)
+
+		err := newUseCase(provider, true).CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		AssertTxSpans(t, exporter.GetSpans(), OutcomeRolledBack, 1)
+
+		t.Cleanup(func() {
+			err = ClearDB(db)
+			assert.NoError(t, err)
+		})
+	})
+}
+
+func Test_SqlxRepoTransactor(t *testing.T) {
+	t.Parallel()
+
+	var (
+		schema = testdb.NewSchema(t, entity.ConnectionString)
+		db     = ConnectSqlx(t, schema.ConnectionString())
+	)
+
+	t.Cleanup(func() {
+		err := db.Close()
+		assert.NoError(t, err)
+	})
+
+	newUseCase := func(provider *sdktrace.TracerProvider, errorExpected bool) *usecase.UseCase[string] {
+		var (
+			transactor     = osqlx.NewTransactor(db)
+			repoTransactor = NewSqlxRepoTransactor(transactor, provider)
+			repositoryA    = sqlxexample.NewTextRepository(repoTransactor)
+			repositoryB    = sqlxexample.NewTextRepository(fault.NewSqlxRepoTransactor(repoTransactor, ExpectedErrorInjector(errorExpected)))
+		)
+		return usecase.NewUseCase(repositoryA, repositoryB, NewTransactor(transactor, provider))
+	}
+
+	t.Run("success_create", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			exporter = tracetest.NewInMemoryExporter()
+			provider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		)
+
+		err := newUseCase(provider, false).CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+		AssertTxSpans(t, exporter.GetSpans(), OutcomeCommitted, 2)
+
+		t.Cleanup(func() {
+			err = ClearDB(db.DB)
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("error_and_rollback", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			exporter = tracetest.NewInMemoryExporter()
+			provider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		)
+
+		err := newUseCase(provider, true).CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		AssertTxSpans(t, exporter.GetSpans(), OutcomeRolledBack, 1)
+
+		t.Cleanup(func() {
+			err = ClearDB(db.DB)
+			assert.NoError(t, err)
+		})
+	})
+}
+
+func Test_PgxRepoTransactor(t *testing.T) {
+	t.Parallel()
+
+	var (
+		globalCtx = context.Background()
+		schema    = testdb.NewSchema(t, entity.ConnectionString)
+		conn      = ConnectPgx(globalCtx, t, schema.ConnectionString())
+		db        = ConnectDB(t, schema.ConnectionString())
+	)
+
+	t.Cleanup(func() {
+		err := conn.Close(globalCtx)
+		assert.NoError(t, err)
+		err = db.Close()
+		assert.NoError(t, err)
+	})
+
+	newUseCase := func(provider *sdktrace.TracerProvider, errorExpected bool) *usecase.UseCase[string] {
+		var (
+			transactor     = opgx.NewTransactor(conn)
+			repoTransactor = NewPgxRepoTransactor(transactor, provider)
+			repositoryA    = pgxexample.NewTextRepository(repoTransactor)
+			repositoryB    = pgxexample.NewTextRepository(fault.NewPgxRepoTransactor(repoTransactor, ExpectedErrorInjector(errorExpected)))
+		)
+		return usecase.NewUseCase(repositoryA, repositoryB, NewTransactor(transactor, provider))
+	}
+
+	t.Run("success_create", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			exporter = tracetest.NewInMemoryExporter()
+			provider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		)
+
+		err := newUseCase(provider, false).CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+		AssertTxSpans(t, exporter.GetSpans(), OutcomeCommitted, 2)
+
+		t.Cleanup(func() {
+			err = ClearDB(db)
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("error_and_rollback", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			exporter = tracetest.NewInMemoryExporter()
+			provider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		)
+
+		err := newUseCase(provider, true).CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		AssertTxSpans(t, exporter.GetSpans(), OutcomeRolledBack, 1)
+
+		t.Cleanup(func() {
+			err = ClearDB(db)
+			assert.NoError(t, err)
+		})
+	})
+}
+
+func Test_GormPlugin(t *testing.T) {
+	t.Parallel()
+
+	var (
+		schema = testdb.NewSchema(t, entity.ConnectionString)
+		db     = ConnectDB(t, schema.ConnectionString())
+	)
+
+	t.Cleanup(func() {
+		err := db.Close()
+		assert.NoError(t, err)
+	})
+
+	newUseCase := func(provider *sdktrace.TracerProvider, errorExpected bool) *usecase.UseCase[string] {
+		gormDB := ConnectGorm(t, db)
+		err := gormDB.Use(NewGormPlugin(provider))
+		assert.NoError(t, err)
+
+		var (
+			transactor  = ogorm.NewTransactor(gormDB)
+			repositoryA = gormexample.NewTextRepository(transactor)
+			repositoryB = gormexample.NewTextRepository(fault.NewGormRepoTransactor(transactor, ExpectedErrorInjector(errorExpected)))
+		)
+		return usecase.NewUseCase(repositoryA.Raw(), repositoryB.Raw(), NewTransactor(transactor, provider))
+	}
+
+	t.Run("success_create", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			exporter = tracetest.NewInMemoryExporter()
+			provider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		)
+
+		err := newUseCase(provider, false).CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+		AssertTxSpans(t, exporter.GetSpans(), OutcomeCommitted, 2)
+
+		t.Cleanup(func() {
+			err = ClearDB(db)
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("error_and_rollback", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			exporter = tracetest.NewInMemoryExporter()
+			provider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		)
+
+		err := newUseCase(provider, true).CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		// the fault is injected into the connection, so the failed statement is traced too.
+		AssertTxSpans(t, exporter.GetSpans(), OutcomeRolledBack, 2)
+
+		t.Cleanup(func() {
+			err = ClearDB(db)
+			assert.NoError(t, err)
+		})
+	})
+}
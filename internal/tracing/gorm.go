@@ -0,0 +1,79 @@
+package tracing
+
+import (
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	gormPluginName = "oniontx:tracing"
+	gormSpanKey    = "oniontx:tracing:span"
+)
+
+// GormPlugin implements [gorm.Plugin] and creates a span per SQL statement.
+//
+// The span is a child of the span obtained from the [gorm.Statement] context,
+// so a repository should pass [context.Context] with [gorm.DB.WithContext].
+type GormPlugin struct {
+	tracer trace.Tracer
+}
+
+// NewGormPlugin returns new GormPlugin.
+func NewGormPlugin(provider trace.TracerProvider) *GormPlugin {
+	return &GormPlugin{
+		tracer: provider.Tracer(instrumentationName),
+	}
+}
+
+// Name returns the plugin name.
+func (p *GormPlugin) Name() string {
+	return gormPluginName
+}
+
+// Initialize registers the plugin callbacks.
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	var (
+		callback = db.Callback()
+		before   = gormPluginName + ":before"
+		after    = gormPluginName + ":after"
+	)
+	for _, err := range []error{
+		callback.Create().Before("gorm:create").Register(before, p.before("Create")),
+		callback.Create().After("gorm:create").Register(after, p.after),
+		callback.Query().Before("gorm:query").Register(before, p.before("Query")),
+		callback.Query().After("gorm:query").Register(after, p.after),
+		callback.Update().Before("gorm:update").Register(before, p.before("Update")),
+		callback.Update().After("gorm:update").Register(after, p.after),
+		callback.Delete().Before("gorm:delete").Register(before, p.before("Delete")),
+		callback.Delete().After("gorm:delete").Register(after, p.after),
+		callback.Row().Before("gorm:row").Register(before, p.before("Row")),
+		callback.Row().After("gorm:row").Register(after, p.after),
+		callback.Raw().Before("gorm:raw").Register(before, p.before("Raw")),
+		callback.Raw().After("gorm:raw").Register(after, p.after),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *GormPlugin) before(name string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		_, span := startStatementSpan(db.Statement.Context, p.tracer, name, "")
+		db.InstanceSet(gormSpanKey, span)
+	}
+}
+
+func (p *GormPlugin) after(db *gorm.DB) {
+	v, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := v.(trace.Span)
+	if !ok {
+		return
+	}
+	span.SetAttributes(AttrStatement.String(db.Statement.SQL.String()))
+	endStatementSpan(span, db.Error)
+}
@@ -0,0 +1,97 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+	"github.com/kozmod/oniontx-examples/internal/fault"
+)
+
+func ConnectDB(t *testing.T, connString string) *sql.DB {
+	connConfig, err := pgx.ParseConfig(connString)
+	assert.NoError(t, err)
+
+	connStr := stdlib.RegisterConnConfig(connConfig)
+	db, err := sql.Open("pgx", connStr)
+	assert.NoError(t, err)
+
+	err = db.Ping()
+	assert.NoError(t, err)
+
+	return db
+}
+
+func ConnectSqlx(t *testing.T, connString string) *sqlx.DB {
+	db, err := sqlx.Connect("postgres", connString)
+	assert.NoError(t, err)
+	return db
+}
+
+func ConnectPgx(ctx context.Context, t *testing.T, connString string) *pgx.Conn {
+	conn, err := pgx.Connect(ctx, connString)
+	assert.NoError(t, err)
+	return conn
+}
+
+func ConnectGorm(t *testing.T, db *sql.DB) *gorm.DB {
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+	assert.NoError(t, err)
+	return gormDB
+}
+
+func ClearDB(db *sql.DB) error {
+	_, err := db.Exec("TRUNCATE TABLE text;")
+	if err != nil {
+		return fmt.Errorf("clear DB: %w", err)
+	}
+	return nil
+}
+
+// AssertTxSpans asserts that the spans contain single WithinTx span with the outcome
+// and `statements` statement spans which are children of the WithinTx span.
+func AssertTxSpans(t *testing.T, spans tracetest.SpanStubs, outcome string, statements int) {
+	t.Helper()
+
+	var (
+		txSpans        tracetest.SpanStubs
+		statementSpans tracetest.SpanStubs
+	)
+	for _, span := range spans {
+		if span.Name == spanWithinTx {
+			txSpans = append(txSpans, span)
+			continue
+		}
+		statementSpans = append(statementSpans, span)
+	}
+
+	if !assert.Len(t, txSpans, 1) {
+		return
+	}
+	txSpan := txSpans[0]
+	assert.Contains(t, txSpan.Attributes, AttrTxOutcome.String(outcome))
+
+	assert.Len(t, statementSpans, statements)
+	for _, span := range statementSpans {
+		assert.Equal(t, txSpan.SpanContext.SpanID(), span.Parent.SpanID())
+		assert.Contains(t, span.Attributes, AttrDBSystem.String(dbSystem))
+	}
+}
+
+// ExpectedErrorInjector returns [fault.Injector] which fails any statement with [entity.ErrExpected] when the error is expected.
+func ExpectedErrorInjector(errorExpected bool) *fault.Injector {
+	if !errorExpected {
+		return fault.NewInjector()
+	}
+	return fault.NewInjector(fault.Rule{Point: fault.BeforeExec, Err: entity.ErrExpected})
+}
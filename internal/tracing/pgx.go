@@ -0,0 +1,72 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	opgx "github.com/kozmod/oniontx/pgx"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	pgxRepoTransactor interface {
+		GetExecutor(ctx context.Context) opgx.Executor
+	}
+)
+
+// PgxRepoTransactor wraps [opgx.Executor] of the decorated transactor with [PgxExecutor].
+type PgxRepoTransactor struct {
+	transactor pgxRepoTransactor
+	tracer     trace.Tracer
+}
+
+// NewPgxRepoTransactor returns new PgxRepoTransactor.
+func NewPgxRepoTransactor(transactor pgxRepoTransactor, provider trace.TracerProvider) *PgxRepoTransactor {
+	return &PgxRepoTransactor{
+		transactor: transactor,
+		tracer:     provider.Tracer(instrumentationName),
+	}
+}
+
+// GetExecutor returns [opgx.Executor] which creates a span per SQL statement.
+func (t *PgxRepoTransactor) GetExecutor(ctx context.Context) opgx.Executor {
+	return &PgxExecutor{
+		executor: t.transactor.GetExecutor(ctx),
+		tracer:   t.tracer,
+	}
+}
+
+// PgxExecutor implements [opgx.Executor] and creates a span per SQL statement.
+type PgxExecutor struct {
+	executor opgx.Executor
+	tracer   trace.Tracer
+}
+
+func (e *PgxExecutor) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	ctx, span := startStatementSpan(ctx, e.tracer, "Exec", sql)
+	tag, err := e.executor.Exec(ctx, sql, arguments...)
+	endStatementSpan(span, err)
+	return tag, err
+}
+
+func (e *PgxExecutor) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	ctx, span := startStatementSpan(ctx, e.tracer, "Query", sql)
+	rows, err := e.executor.Query(ctx, sql, args...)
+	endStatementSpan(span, err)
+	return rows, err
+}
+
+func (e *PgxExecutor) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	ctx, span := startStatementSpan(ctx, e.tracer, "QueryRow", sql)
+	row := e.executor.QueryRow(ctx, sql, args...)
+	endStatementSpan(span, nil)
+	return row
+}
+
+func (e *PgxExecutor) Prepare(ctx context.Context, name, sql string) (*pgconn.StatementDescription, error) {
+	ctx, span := startStatementSpan(ctx, e.tracer, "Prepare", sql)
+	sd, err := e.executor.Prepare(ctx, name, sql)
+	endStatementSpan(span, err)
+	return sd, err
+}
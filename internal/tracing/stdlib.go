@@ -0,0 +1,128 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+
+	osqlx "github.com/kozmod/oniontx/sqlx"
+	ostdlib "github.com/kozmod/oniontx/stdlib"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	stdlibRepoTransactor interface {
+		GetExecutor(ctx context.Context) ostdlib.Executor
+	}
+
+	sqlxRepoTransactor interface {
+		GetExecutor(ctx context.Context) osqlx.Executor
+	}
+)
+
+// StdlibRepoTransactor wraps [ostdlib.Executor] of the decorated transactor with [SQLExecutor].
+type StdlibRepoTransactor struct {
+	transactor stdlibRepoTransactor
+	tracer     trace.Tracer
+}
+
+// NewStdlibRepoTransactor returns new StdlibRepoTransactor.
+func NewStdlibRepoTransactor(transactor stdlibRepoTransactor, provider trace.TracerProvider) *StdlibRepoTransactor {
+	return &StdlibRepoTransactor{
+		transactor: transactor,
+		tracer:     provider.Tracer(instrumentationName),
+	}
+}
+
+// GetExecutor returns [ostdlib.Executor] which creates a span per SQL statement.
+func (t *StdlibRepoTransactor) GetExecutor(ctx context.Context) ostdlib.Executor {
+	return &SQLExecutor{
+		executor: t.transactor.GetExecutor(ctx),
+		tracer:   t.tracer,
+	}
+}
+
+// SqlxRepoTransactor wraps [osqlx.Executor] of the decorated transactor with [SQLExecutor].
+type SqlxRepoTransactor struct {
+	transactor sqlxRepoTransactor
+	tracer     trace.Tracer
+}
+
+// NewSqlxRepoTransactor returns new SqlxRepoTransactor.
+func NewSqlxRepoTransactor(transactor sqlxRepoTransactor, provider trace.TracerProvider) *SqlxRepoTransactor {
+	return &SqlxRepoTransactor{
+		transactor: transactor,
+		tracer:     provider.Tracer(instrumentationName),
+	}
+}
+
+// GetExecutor returns [osqlx.Executor] which creates a span per SQL statement.
+func (t *SqlxRepoTransactor) GetExecutor(ctx context.Context) osqlx.Executor {
+	return &SQLExecutor{
+		executor: t.transactor.GetExecutor(ctx),
+		tracer:   t.tracer,
+	}
+}
+
+// SQLExecutor implements [ostdlib.Executor] and [osqlx.Executor] and creates a span per SQL statement.
+//
+// Methods without [context.Context] create root spans.
+type SQLExecutor struct {
+	executor ostdlib.Executor
+	tracer   trace.Tracer
+}
+
+func (e *SQLExecutor) Exec(query string, args ...any) (sql.Result, error) {
+	_, span := startStatementSpan(context.Background(), e.tracer, "Exec", query)
+	res, err := e.executor.Exec(query, args...)
+	endStatementSpan(span, err)
+	return res, err
+}
+
+func (e *SQLExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, span := startStatementSpan(ctx, e.tracer, "ExecContext", query)
+	res, err := e.executor.ExecContext(ctx, query, args...)
+	endStatementSpan(span, err)
+	return res, err
+}
+
+func (e *SQLExecutor) Query(query string, args ...any) (*sql.Rows, error) {
+	_, span := startStatementSpan(context.Background(), e.tracer, "Query", query)
+	rows, err := e.executor.Query(query, args...)
+	endStatementSpan(span, err)
+	return rows, err
+}
+
+func (e *SQLExecutor) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ctx, span := startStatementSpan(ctx, e.tracer, "QueryContext", query)
+	rows, err := e.executor.QueryContext(ctx, query, args...)
+	endStatementSpan(span, err)
+	return rows, err
+}
+
+func (e *SQLExecutor) QueryRow(query string, args ...any) *sql.Row {
+	_, span := startStatementSpan(context.Background(), e.tracer, "QueryRow", query)
+	row := e.executor.QueryRow(query, args...)
+	endStatementSpan(span, row.Err())
+	return row
+}
+
+func (e *SQLExecutor) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, span := startStatementSpan(ctx, e.tracer, "QueryRowContext", query)
+	row := e.executor.QueryRowContext(ctx, query, args...)
+	endStatementSpan(span, row.Err())
+	return row
+}
+
+func (e *SQLExecutor) Prepare(query string) (*sql.Stmt, error) {
+	_, span := startStatementSpan(context.Background(), e.tracer, "Prepare", query)
+	stmt, err := e.executor.Prepare(query)
+	endStatementSpan(span, err)
+	return stmt, err
+}
+
+func (e *SQLExecutor) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	ctx, span := startStatementSpan(ctx, e.tracer, "PrepareContext", query)
+	stmt, err := e.executor.PrepareContext(ctx, query)
+	endStatementSpan(span, err)
+	return stmt, err
+}
@@ -0,0 +1,97 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "github.com/kozmod/oniontx-examples/internal/tracing"
+
+	spanWithinTx = "WithinTx"
+
+	AttrTxDepth   = attribute.Key("tx.depth")
+	AttrTxOutcome = attribute.Key("tx.outcome")
+	AttrDBSystem  = attribute.Key("db.system")
+	AttrStatement = attribute.Key("db.statement")
+
+	OutcomeCommitted  = "committed"
+	OutcomeRolledBack = "rolled_back"
+	OutcomePending    = "pending"
+
+	dbSystem = "postgresql"
+)
+
+type (
+	transactor interface {
+		WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error)
+	}
+)
+
+// depthKey carries the depth of nested calls of the particular Transactor,
+// so the highest level call of a Transactor called within another one is still marked as `committed`.
+type depthKey struct {
+	transactor *Transactor
+}
+
+// Transactor decorates a transactor and creates a span per WithinTx call.
+//
+// The highest level span is marked as `committed` or `rolled_back`,
+// nested spans are marked as `rolled_back` or `pending`, since a nested call does not commit the transaction.
+type Transactor struct {
+	transactor transactor
+	tracer     trace.Tracer
+}
+
+// NewTransactor returns new Transactor.
+func NewTransactor(transactor transactor, provider trace.TracerProvider) *Transactor {
+	return &Transactor{
+		transactor: transactor,
+		tracer:     provider.Tracer(instrumentationName),
+	}
+}
+
+// WithinTx calls WithinTx of the decorated transactor within a new span.
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	depth, _ := ctx.Value(depthKey{transactor: t}).(int)
+
+	ctx, span := t.tracer.Start(ctx, spanWithinTx, trace.WithAttributes(AttrTxDepth.Int(depth)))
+	defer span.End()
+
+	err = t.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		return fn(context.WithValue(ctx, depthKey{transactor: t}, depth+1))
+	})
+
+	switch {
+	case err != nil:
+		span.SetAttributes(AttrTxOutcome.String(OutcomeRolledBack))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case depth > 0:
+		span.SetAttributes(AttrTxOutcome.String(OutcomePending))
+	default:
+		span.SetAttributes(AttrTxOutcome.String(OutcomeCommitted))
+	}
+	return err
+}
+
+func startStatementSpan(ctx context.Context, tracer trace.Tracer, name, query string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			AttrDBSystem.String(dbSystem),
+			AttrStatement.String(query),
+		),
+	)
+}
+
+func endStatementSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
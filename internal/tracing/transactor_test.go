@@ -0,0 +1,103 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type transactorFunc func(ctx context.Context, fn func(ctx context.Context) error) error
+
+func (f transactorFunc) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return f(ctx, fn)
+}
+
+func passThrough(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func Test_Transactor(t *testing.T) {
+	t.Run("nested_commit", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			exporter   = tracetest.NewInMemoryExporter()
+			provider   = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			transactor = NewTransactor(transactorFunc(passThrough), provider)
+		)
+
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			return transactor.WithinTx(ctx, func(ctx context.Context) error {
+				return nil
+			})
+		})
+		assert.NoError(t, err)
+
+		spans := exporter.GetSpans()
+		assert.Len(t, spans, 2)
+
+		inner, outer := spans[0], spans[1]
+		assert.Equal(t, spanWithinTx, inner.Name)
+		assert.Equal(t, outer.SpanContext.SpanID(), inner.Parent.SpanID())
+		assert.Contains(t, inner.Attributes, AttrTxDepth.Int(1))
+		assert.Contains(t, inner.Attributes, AttrTxOutcome.String(OutcomePending))
+
+		assert.Equal(t, spanWithinTx, outer.Name)
+		assert.False(t, outer.Parent.IsValid())
+		assert.Contains(t, outer.Attributes, AttrTxDepth.Int(0))
+		assert.Contains(t, outer.Attributes, AttrTxOutcome.String(OutcomeCommitted))
+	})
+	t.Run("nested_rollback", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			expErr     = fmt.Errorf("some_error")
+			exporter   = tracetest.NewInMemoryExporter()
+			provider   = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			transactor = NewTransactor(transactorFunc(passThrough), provider)
+		)
+
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			return transactor.WithinTx(ctx, func(ctx context.Context) error {
+				return expErr
+			})
+		})
+		assert.ErrorIs(t, err, expErr)
+
+		spans := exporter.GetSpans()
+		assert.Len(t, spans, 2)
+		for i, depth := range []int{1, 0} {
+			span := spans[i]
+			assert.Contains(t, span.Attributes, AttrTxDepth.Int(depth))
+			assert.Contains(t, span.Attributes, AttrTxOutcome.String(OutcomeRolledBack))
+			assert.Equal(t, codes.Error, span.Status.Code)
+		}
+	})
+	t.Run("different_transactors_depth", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			exporter = tracetest.NewInMemoryExporter()
+			provider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			outer    = NewTransactor(transactorFunc(passThrough), provider)
+			inner    = NewTransactor(transactorFunc(passThrough), provider)
+		)
+
+		err := outer.WithinTx(ctx, func(ctx context.Context) error {
+			return inner.WithinTx(ctx, func(ctx context.Context) error {
+				return nil
+			})
+		})
+		assert.NoError(t, err)
+
+		spans := exporter.GetSpans()
+		assert.Len(t, spans, 2)
+		for _, span := range spans {
+			assert.Contains(t, span.Attributes, AttrTxDepth.Int(0))
+			assert.Contains(t, span.Attributes, AttrTxOutcome.String(OutcomeCommitted))
+		}
+		assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+	})
+}
@@ -0,0 +1,137 @@
+package txtest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Outcome of the transaction.
+type Outcome string
+
+const (
+	OutcomeCommitted  Outcome = "committed"
+	OutcomeRolledBack Outcome = "rolled_back"
+)
+
+// Call is the recorded call of [Transactor.WithinTx].
+type Call struct {
+	// Depth of the call: 1 - the root transaction, >1 - nested calls.
+	Depth int
+	// Outcome is OutcomeCommitted when the function has returned nil.
+	Outcome Outcome
+	// Err returned by the function.
+	Err error
+}
+
+type depthKey struct {
+	transactor *Transactor
+}
+
+// Transactor is the test transactor which always calls the function and records each call.
+//
+// Transactor doesn't manage any real transaction:
+// the root call "commits" when the function returns nil and "rolls back" otherwise.
+// The panic within the root call is recovered and returned as an error.
+type Transactor struct {
+	mx    sync.Mutex
+	calls []Call
+}
+
+// NewTransactor returns new Transactor.
+func NewTransactor() *Transactor {
+	return &Transactor{}
+}
+
+// WithinTx calls the function and records the call.
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	depth, _ := ctx.Value(depthKey{transactor: t}).(int)
+	depth++
+
+	if depth == 1 {
+		defer func() {
+			if p := recover(); p != nil {
+				err = fmt.Errorf("txtest - panic [%v]", p)
+				t.record(depth, err)
+			}
+		}()
+	}
+
+	err = fn(context.WithValue(ctx, depthKey{transactor: t}, depth))
+	t.record(depth, err)
+	return err
+}
+
+func (t *Transactor) record(depth int, err error) {
+	outcome := OutcomeCommitted
+	if err != nil {
+		outcome = OutcomeRolledBack
+	}
+
+	t.mx.Lock()
+	defer t.mx.Unlock()
+	t.calls = append(t.calls, Call{
+		Depth:   depth,
+		Outcome: outcome,
+		Err:     err,
+	})
+}
+
+// Calls returns all recorded calls in order of completion (nested calls go before the root one).
+func (t *Transactor) Calls() []Call {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+	return append([]Call(nil), t.calls...)
+}
+
+// RootCalls returns the recorded calls of the root transactions.
+func (t *Transactor) RootCalls() []Call {
+	var roots []Call
+	for _, call := range t.Calls() {
+		if call.Depth == 1 {
+			roots = append(roots, call)
+		}
+	}
+	return roots
+}
+
+// AssertCalls asserts the number of all calls (root and nested).
+func (t *Transactor) AssertCalls(tb testing.TB, expected int) bool {
+	tb.Helper()
+	return assert.Len(tb, t.Calls(), expected, "transactor calls")
+}
+
+// AssertCommitted asserts that the last root transaction has been committed.
+func (t *Transactor) AssertCommitted(tb testing.TB) bool {
+	tb.Helper()
+	return t.assertOutcome(tb, OutcomeCommitted)
+}
+
+// AssertRolledBack asserts that the last root transaction has been rolled back.
+func (t *Transactor) AssertRolledBack(tb testing.TB) bool {
+	tb.Helper()
+	return t.assertOutcome(tb, OutcomeRolledBack)
+}
+
+// AssertRolledBackWith asserts that the last root transaction has been rolled back with the target error.
+func (t *Transactor) AssertRolledBackWith(tb testing.TB, target error) bool {
+	tb.Helper()
+	if !t.assertOutcome(tb, OutcomeRolledBack) {
+		return false
+	}
+	roots := t.RootCalls()
+	return assert.ErrorIs(tb, roots[len(roots)-1].Err, target)
+}
+
+func (t *Transactor) assertOutcome(tb testing.TB, expected Outcome) bool {
+	tb.Helper()
+	roots := t.RootCalls()
+	if !assert.NotEmpty(tb, roots, "no root transaction has been called") {
+		return false
+	}
+	last := roots[len(roots)-1]
+	return assert.Equal(tb, expected, last.Outcome, "root transaction outcome: %v", last.Err)
+}
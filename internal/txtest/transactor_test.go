@@ -0,0 +1,99 @@
+package txtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+)
+
+func Test_Transactor(t *testing.T) {
+	t.Run("committed", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			transactor = NewTransactor()
+			called     bool
+		)
+
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.True(t, called)
+		assert.Equal(t, []Call{{Depth: 1, Outcome: OutcomeCommitted}}, transactor.Calls())
+		transactor.AssertCalls(t, 1)
+		transactor.AssertCommitted(t)
+	})
+	t.Run("rolled_back", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			transactor = NewTransactor()
+		)
+
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			return entity.ErrExpected
+		})
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.Equal(t, []Call{{Depth: 1, Outcome: OutcomeRolledBack, Err: entity.ErrExpected}}, transactor.Calls())
+		transactor.AssertRolledBack(t)
+		transactor.AssertRolledBackWith(t, entity.ErrExpected)
+	})
+	t.Run("nested", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			transactor = NewTransactor()
+		)
+
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+				return nil
+			})
+			assert.NoError(t, err)
+			return transactor.WithinTx(ctx, func(ctx context.Context) error {
+				return entity.ErrExpected
+			})
+		})
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.Equal(t, []Call{
+			{Depth: 2, Outcome: OutcomeCommitted},
+			{Depth: 2, Outcome: OutcomeRolledBack, Err: entity.ErrExpected},
+			{Depth: 1, Outcome: OutcomeRolledBack, Err: entity.ErrExpected},
+		}, transactor.Calls())
+		assert.Len(t, transactor.RootCalls(), 1)
+		transactor.AssertRolledBackWith(t, entity.ErrExpected)
+	})
+	t.Run("panic", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			transactor = NewTransactor()
+		)
+
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			return transactor.WithinTx(ctx, func(ctx context.Context) error {
+				panic("some_panic")
+			})
+		})
+		assert.ErrorContains(t, err, "some_panic")
+		transactor.AssertCalls(t, 1)
+		transactor.AssertRolledBack(t)
+	})
+	t.Run("failed_assertion", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			transactor = NewTransactor()
+			mockT      = new(testing.T)
+		)
+
+		assert.False(t, transactor.AssertCommitted(mockT))
+
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.False(t, transactor.AssertRolledBack(mockT))
+		assert.True(t, mockT.Failed())
+	})
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+)
+
+type (
+	repository[T any] interface {
+		Insert(ctx context.Context, val T) error
+	}
+
+	useCase[T any] interface {
+		CreateTextRecords(ctx context.Context, text T) error
+	}
+
+	transactor interface {
+		WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error)
+	}
+)
+
+// StepOption applied to Step.
+type StepOption func(s *stepOptions)
+
+type stepOptions struct {
+	optional bool
+	wrapErr  func(name string, err error) error
+}
+
+// Optional marks the step as optional: the error of the step doesn't stop [UseCases].
+//
+// Errors of the canceled [context.Context] are never ignored.
+// Note that Postgres aborts the transaction after a failed statement,
+// so the following steps fail anyway if the optional one has failed on the database side.
+func Optional() StepOption {
+	return func(s *stepOptions) {
+		s.optional = true
+	}
+}
+
+// WithWrapErr sets the function wrapping the error of the step (default "text usecase <name>: <err>").
+func WithWrapErr(wrapErr func(name string, err error) error) StepOption {
+	return func(s *stepOptions) {
+		s.wrapErr = wrapErr
+	}
+}
+
+// Step is a named use case of [UseCases].
+type Step[T any] struct {
+	name    string
+	useCase useCase[T]
+	opts    stepOptions
+}
+
+// NewStep returns new Step.
+func NewStep[T any](name string, useCase useCase[T], opts ...StepOption) Step[T] {
+	step := Step[T]{
+		name:    name,
+		useCase: useCase,
+		opts: stepOptions{
+			wrapErr: wrapStepErr,
+		},
+	}
+	for _, opt := range opts {
+		opt(&step.opts)
+	}
+	return step
+}
+
+func wrapStepErr(name string, err error) error {
+	return fmt.Errorf("text usecase %s: %w", name, err)
+}
+
+// UseCases calls the steps one by one within one transaction.
+type UseCases[T any] struct {
+	steps []Step[T]
+
+	transactor transactor
+}
+
+// NewUseCases returns new UseCases.
+func NewUseCases[T any](transactor transactor, steps ...Step[T]) *UseCases[T] {
+	return &UseCases[T]{
+		steps:      steps,
+		transactor: transactor,
+	}
+}
+
+func (u *UseCases[T]) CreateTextRecords(ctx context.Context, text T) error {
+	return u.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		for _, step := range u.steps {
+			err := step.useCase.CreateTextRecords(ctx, text)
+			if err == nil {
+				continue
+			}
+			if step.opts.optional && ctx.Err() == nil {
+				continue
+			}
+			return step.opts.wrapErr(step.name, err)
+		}
+		return nil
+	})
+}
+
+// UseCase inserts a text record with each of two repositories within one transaction.
+type UseCase[T any] struct {
+	textRepoA repository[T]
+	textRepoB repository[T]
+
+	transactor transactor
+}
+
+// NewUseCase returns new UseCase.
+func NewUseCase[T any](textRepoA repository[T], textRepoB repository[T], transactor transactor) *UseCase[T] {
+	return &UseCase[T]{
+		textRepoA:  textRepoA,
+		textRepoB:  textRepoB,
+		transactor: transactor,
+	}
+}
+
+func (u *UseCase[T]) CreateTextRecords(ctx context.Context, text T) error {
+	return u.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := u.textRepoA.Insert(ctx, text)
+		if err != nil {
+			return fmt.Errorf("text repo A: %w", err)
+		}
+
+		err = u.textRepoB.Insert(ctx, text)
+		if err != nil {
+			return fmt.Errorf("text repo B: %w", err)
+		}
+		return nil
+	})
+}
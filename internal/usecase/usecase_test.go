@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/entity"
+	"github.com/kozmod/oniontx-examples/internal/memory"
+)
+
+const (
+	textRecord = "text_A"
+)
+
+// errTextRepository fails any insert with entity.ErrExpected.
+type errTextRepository struct{}
+
+func (errTextRepository) Insert(context.Context, string) error {
+	return entity.ErrExpected
+}
+
+func Test_UseCase(t *testing.T) {
+	t.Run("success_create", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			store      = memory.NewStore()
+			transactor = memory.NewTransactor(store)
+			repository = memory.NewTextRepository(transactor)
+			useCase    = NewUseCase(repository, repository, transactor)
+		)
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{textRecord, textRecord}, store.Texts())
+	})
+	t.Run("error_and_rollback", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			store      = memory.NewStore()
+			transactor = memory.NewTransactor(store)
+			repository = memory.NewTextRepository(transactor)
+			useCase    = NewUseCase(repository, errTextRepository{}, transactor)
+		)
+
+		err := useCase.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.ErrorContains(t, err, "text repo B")
+		assert.Empty(t, store.Texts())
+	})
+}
+
+// recordUseCase records the name of the step and returns err.
+type recordUseCase struct {
+	name   string
+	err    error
+	called *[]string
+}
+
+func (u recordUseCase) CreateTextRecords(context.Context, string) error {
+	*u.called = append(*u.called, u.name)
+	return u.err
+}
+
+// canceledUseCase cancels the context and returns its error.
+type canceledUseCase struct {
+	cancel context.CancelFunc
+}
+
+func (u canceledUseCase) CreateTextRecords(ctx context.Context, _ string) error {
+	u.cancel()
+	return ctx.Err()
+}
+
+func Test_UseCases(t *testing.T) {
+	t.Run("success_create", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			store      = memory.NewStore()
+			transactor = memory.NewTransactor(store)
+			repository = memory.NewTextRepository(transactor)
+			useCases   = NewUseCases(
+				transactor,
+				NewStep("A", NewUseCase(repository, repository, transactor)),
+				NewStep("B", NewUseCase(repository, repository, transactor)),
+				NewStep("C", NewUseCase(repository, repository, transactor)),
+			)
+		)
+
+		err := useCases.CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+		assert.Len(t, store.Texts(), 6)
+	})
+	t.Run("all_steps_called", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			called     []string
+			transactor = memory.NewTransactor(memory.NewStore())
+			useCases   = NewUseCases(
+				transactor,
+				NewStep[string]("A", recordUseCase{name: "A", called: &called}),
+				NewStep[string]("B", recordUseCase{name: "B", called: &called}),
+				NewStep[string]("C", recordUseCase{name: "C", called: &called}),
+				NewStep[string]("D", recordUseCase{name: "D", called: &called}),
+			)
+		)
+
+		err := useCases.CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"A", "B", "C", "D"}, called)
+	})
+	t.Run("step_error_and_rollback", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			called     []string
+			store      = memory.NewStore()
+			transactor = memory.NewTransactor(store)
+			repository = memory.NewTextRepository(transactor)
+			useCases   = NewUseCases(
+				transactor,
+				NewStep("A", NewUseCase(repository, repository, transactor)),
+				NewStep[string]("B", recordUseCase{name: "B", err: entity.ErrExpected, called: &called}),
+				NewStep[string]("C", recordUseCase{name: "C", called: &called}),
+			)
+		)
+
+		err := useCases.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.ErrorContains(t, err, "text usecase B")
+		assert.Equal(t, []string{"B"}, called)
+		assert.Empty(t, store.Texts())
+	})
+	t.Run("nested_error_and_rollback", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			store      = memory.NewStore()
+			transactor = memory.NewTransactor(store)
+			repository = memory.NewTextRepository(transactor)
+			useCases   = NewUseCases(
+				transactor,
+				NewStep("A", NewUseCase(repository, repository, transactor)),
+				NewStep("B", NewUseCase(repository, errTextRepository{}, transactor)),
+			)
+		)
+
+		err := useCases.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.ErrorContains(t, err, "text usecase B: text repo B")
+		assert.Empty(t, store.Texts())
+	})
+	t.Run("optional_step_error", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			called     []string
+			store      = memory.NewStore()
+			transactor = memory.NewTransactor(store)
+			repository = memory.NewTextRepository(transactor)
+			useCases   = NewUseCases(
+				transactor,
+				NewStep[string]("A", recordUseCase{name: "A", err: entity.ErrExpected, called: &called}, Optional()),
+				NewStep("B", NewUseCase(repository, repository, transactor)),
+				NewStep[string]("C", recordUseCase{name: "C", called: &called}),
+			)
+		)
+
+		err := useCases.CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"A", "C"}, called)
+		assert.Equal(t, []string{textRecord, textRecord}, store.Texts())
+	})
+	t.Run("optional_step_canceled", func(t *testing.T) {
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			called      []string
+			transactor  = memory.NewTransactor(memory.NewStore())
+			useCases    = NewUseCases(
+				transactor,
+				NewStep[string]("A", canceledUseCase{cancel: cancel}, Optional()),
+				NewStep[string]("B", recordUseCase{name: "B", called: &called}),
+			)
+		)
+		defer cancel()
+
+		err := useCases.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, called)
+	})
+	t.Run("wrap_error", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			called     []string
+			transactor = memory.NewTransactor(memory.NewStore())
+			useCases   = NewUseCases(
+				transactor,
+				NewStep[string]("A", recordUseCase{name: "A", err: entity.ErrExpected, called: &called},
+					WithWrapErr(func(name string, err error) error {
+						return fmt.Errorf("step [%s] failed: %w", name, err)
+					}),
+				),
+			)
+		)
+
+		err := useCases.CreateTextRecords(ctx, textRecord)
+		assert.ErrorIs(t, err, entity.ErrExpected)
+		assert.ErrorContains(t, err, "step [A] failed")
+	})
+	t.Run("no_steps", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			store      = memory.NewStore()
+			transactor = memory.NewTransactor(store)
+			useCases   = NewUseCases[string](transactor)
+		)
+
+		err := useCases.CreateTextRecords(ctx, textRecord)
+		assert.NoError(t, err)
+		assert.Empty(t, store.Texts())
+	})
+}
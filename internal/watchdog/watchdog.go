@@ -0,0 +1,192 @@
+package watchdog
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+var (
+	ErrThresholdExceeded = fmt.Errorf("transaction exceeded threshold")
+)
+
+type (
+	transactor interface {
+		WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error)
+	}
+
+	// Clock provides the current time.
+	Clock interface {
+		Now() time.Time
+	}
+)
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Report describes a transaction which is in flight longer than the threshold.
+type Report struct {
+	ID      uint64
+	Started time.Time
+	Elapsed time.Duration
+	// Stack of the goroutine which opened the transaction.
+	Stack []byte
+	// Canceled is true when the transaction's [context.Context] was canceled.
+	Canceled bool
+}
+
+// Option applied to Watchdog.
+type Option func(w *Watchdog)
+
+// WithClock sets the Clock (default [time.Now]).
+func WithClock(clock Clock) Option {
+	return func(w *Watchdog) {
+		w.clock = clock
+	}
+}
+
+// WithCancel enables canceling of [context.Context] of the transactions exceeded the threshold.
+//
+// [context.Cause] of the canceled [context.Context] is [ErrThresholdExceeded].
+func WithCancel() Option {
+	return func(w *Watchdog) {
+		w.cancel = true
+	}
+}
+
+type inFlight struct {
+	started  time.Time
+	stack    []byte
+	cancel   context.CancelCauseFunc
+	reported bool
+}
+
+// nestedKey marks nested calls of the particular Watchdog,
+// so a Watchdog called within another one still tracks its transactions.
+type nestedKey struct {
+	watchdog *Watchdog
+}
+
+// Watchdog decorates a transactor and tracks transactions in flight.
+//
+// Only the highest level WithinTx call is tracked.
+// [Watchdog.Check] reports each transaction which exceeded the threshold once.
+type Watchdog struct {
+	transactor transactor
+	threshold  time.Duration
+	report     func(report Report)
+	clock      Clock
+	cancel     bool
+
+	mx       sync.Mutex
+	seq      uint64
+	inFlight map[uint64]*inFlight
+}
+
+// NewWatchdog returns new Watchdog.
+func NewWatchdog(transactor transactor, threshold time.Duration, report func(report Report), opts ...Option) *Watchdog {
+	w := Watchdog{
+		transactor: transactor,
+		threshold:  threshold,
+		report:     report,
+		clock:      systemClock{},
+		inFlight:   make(map[uint64]*inFlight),
+	}
+	for _, opt := range opts {
+		opt(&w)
+	}
+	return &w
+}
+
+// WithinTx calls WithinTx of the decorated transactor and tracks the transaction until it is finished.
+func (w *Watchdog) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if nested, _ := ctx.Value(nestedKey{watchdog: w}).(bool); nested {
+		return w.transactor.WithinTx(ctx, fn)
+	}
+
+	ctx, cancel := context.WithCancelCause(context.WithValue(ctx, nestedKey{watchdog: w}, true))
+	defer cancel(nil)
+
+	id := w.track(cancel)
+	defer w.untrack(id)
+
+	return w.transactor.WithinTx(ctx, fn)
+}
+
+// Check reports the transactions exceeded the threshold and cancels them when [WithCancel] is set.
+func (w *Watchdog) Check() {
+	var (
+		now     = w.clock.Now()
+		reports []Report
+	)
+
+	w.mx.Lock()
+	for id, tx := range w.inFlight {
+		elapsed := now.Sub(tx.started)
+		if tx.reported || elapsed < w.threshold {
+			continue
+		}
+		tx.reported = true
+		if w.cancel {
+			tx.cancel(ErrThresholdExceeded)
+		}
+		reports = append(reports, Report{
+			ID:       id,
+			Started:  tx.started,
+			Elapsed:  elapsed,
+			Stack:    tx.stack,
+			Canceled: w.cancel,
+		})
+	}
+	w.mx.Unlock()
+
+	for _, report := range reports {
+		w.report(report)
+	}
+}
+
+// Run calls [Watchdog.Check] with the interval until [context.Context] is done.
+func (w *Watchdog) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.Check()
+		}
+	}
+}
+
+// InFlight returns the number of transactions in flight.
+func (w *Watchdog) InFlight() int {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+	return len(w.inFlight)
+}
+
+func (w *Watchdog) track(cancel context.CancelCauseFunc) uint64 {
+	tx := inFlight{
+		started: w.clock.Now(),
+		stack:   debug.Stack(),
+		cancel:  cancel,
+	}
+
+	w.mx.Lock()
+	defer w.mx.Unlock()
+	w.seq++
+	w.inFlight[w.seq] = &tx
+	return w.seq
+}
+
+func (w *Watchdog) untrack(id uint64) {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+	delete(w.inFlight, id)
+}
@@ -0,0 +1,194 @@
+package watchdog
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kozmod/oniontx-examples/internal/usecase"
+)
+
+const (
+	textRecord = "text_A"
+	threshold  = 5 * time.Second
+)
+
+type fakeClock struct {
+	mx  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	c.now = c.now.Add(d)
+}
+
+type transactorFunc func(ctx context.Context, fn func(ctx context.Context) error) error
+
+func (f transactorFunc) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return f(ctx, fn)
+}
+
+func passThrough(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// slowTextRepository blocks Insert until it is released or [context.Context] is done.
+type slowTextRepository struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func newSlowTextRepository() *slowTextRepository {
+	return &slowTextRepository{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+}
+
+func (r *slowTextRepository) Insert(ctx context.Context, _ string) error {
+	close(r.started)
+	select {
+	case <-r.release:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("slow repository: %w", context.Cause(ctx))
+	}
+}
+
+type fastTextRepository struct{}
+
+func (fastTextRepository) Insert(context.Context, string) error {
+	return nil
+}
+
+type reportRecorder struct {
+	mx      sync.Mutex
+	reports []Report
+}
+
+func (r *reportRecorder) record(report Report) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	r.reports = append(r.reports, report)
+}
+
+func (r *reportRecorder) Reports() []Report {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	return append([]Report(nil), r.reports...)
+}
+
+func Test_Watchdog(t *testing.T) {
+	t.Run("report_slow_transaction", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			clock      = &fakeClock{now: time.Now()}
+			recorder   = new(reportRecorder)
+			repository = newSlowTextRepository()
+			watchdog   = NewWatchdog(transactorFunc(passThrough), threshold, recorder.record, WithClock(clock))
+			useCase    = usecase.NewUseCase(fastTextRepository{}, repository, watchdog)
+			errCh      = make(chan error)
+		)
+
+		go func() {
+			errCh <- useCase.CreateTextRecords(ctx, textRecord)
+		}()
+		<-repository.started
+		assert.Equal(t, 1, watchdog.InFlight())
+
+		clock.Advance(threshold - time.Second)
+		watchdog.Check()
+		assert.Empty(t, recorder.Reports())
+
+		clock.Advance(time.Second)
+		watchdog.Check()
+		watchdog.Check()
+
+		reports := recorder.Reports()
+		if assert.Len(t, reports, 1) {
+			report := reports[0]
+			assert.Equal(t, threshold, report.Elapsed)
+			assert.False(t, report.Canceled)
+			assert.Contains(t, string(report.Stack), "usecase.(*UseCase[...]).CreateTextRecords")
+		}
+
+		close(repository.release)
+		assert.NoError(t, <-errCh)
+		assert.Equal(t, 0, watchdog.InFlight())
+	})
+	t.Run("cancel_slow_transaction", func(t *testing.T) {
+		var (
+			ctx        = context.Background()
+			clock      = &fakeClock{now: time.Now()}
+			recorder   = new(reportRecorder)
+			repository = newSlowTextRepository()
+			watchdog   = NewWatchdog(transactorFunc(passThrough), threshold, recorder.record, WithClock(clock), WithCancel())
+			useCase    = usecase.NewUseCase(fastTextRepository{}, repository, watchdog)
+			errCh      = make(chan error)
+		)
+
+		go func() {
+			errCh <- useCase.CreateTextRecords(ctx, textRecord)
+		}()
+		<-repository.started
+
+		clock.Advance(threshold)
+		watchdog.Check()
+
+		err := <-errCh
+		assert.ErrorIs(t, err, ErrThresholdExceeded)
+		assert.Equal(t, 0, watchdog.InFlight())
+
+		reports := recorder.Reports()
+		if assert.Len(t, reports, 1) {
+			assert.True(t, reports[0].Canceled)
+		}
+	})
+	t.Run("nested_tracked_once", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			recorder = new(reportRecorder)
+			watchdog = NewWatchdog(transactorFunc(passThrough), threshold, recorder.record)
+		)
+
+		err := watchdog.WithinTx(ctx, func(ctx context.Context) error {
+			return watchdog.WithinTx(ctx, func(ctx context.Context) error {
+				assert.Equal(t, 1, watchdog.InFlight())
+				return nil
+			})
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, watchdog.InFlight())
+	})
+	t.Run("different_watchdogs_tracked", func(t *testing.T) {
+		var (
+			ctx      = context.Background()
+			recorder = new(reportRecorder)
+			outer    = NewWatchdog(transactorFunc(passThrough), threshold, recorder.record)
+			inner    = NewWatchdog(transactorFunc(passThrough), threshold, recorder.record)
+		)
+
+		err := outer.WithinTx(ctx, func(ctx context.Context) error {
+			return inner.WithinTx(ctx, func(ctx context.Context) error {
+				assert.Equal(t, 1, outer.InFlight())
+				assert.Equal(t, 1, inner.InFlight())
+				return nil
+			})
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, outer.InFlight())
+		assert.Equal(t, 0, inner.InFlight())
+	})
+}
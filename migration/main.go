@@ -2,7 +2,6 @@ package main
 
 import (
 	"database/sql"
-	"embed"
 	"flag"
 	"log"
 	"strings"
@@ -12,6 +11,8 @@ import (
 	"github.com/jackc/pgx/v4/stdlib"
 	"github.com/pkg/errors"
 	"github.com/pressly/goose/v3"
+
+	"github.com/kozmod/oniontx-examples/migration/migrations"
 )
 
 const (
@@ -20,9 +21,6 @@ const (
 	downCmd   = "down"
 )
 
-//go:embed migrations/*.sql
-var embedMigrations embed.FS
-
 func main() {
 	log.Printf("migration start")
 
@@ -47,17 +45,17 @@ func main() {
 		log.Fatal(errors.WithMessage(err, "ping db"))
 	}
 
-	goose.SetBaseFS(embedMigrations)
+	goose.SetBaseFS(migrations.FS)
 
 	log.Printf("migration start with command '%s' with connection string '%s'", *cmd, *connString)
 
 	switch {
 	case strings.EqualFold(*cmd, statusCmd):
-		err = goose.Status(db, "migrations")
+		err = goose.Status(db, ".")
 	case strings.EqualFold(*cmd, upCmd):
-		err = goose.Up(db, "migrations")
+		err = goose.Up(db, ".")
 	case strings.EqualFold(*cmd, downCmd):
-		err = goose.Down(db, "migrations")
+		err = goose.Down(db, ".")
 	default:
 		log.Fatal("cmd is not fit for 'goose'")
 	}
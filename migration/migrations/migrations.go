@@ -0,0 +1,9 @@
+// Package migrations contains the embedded goose migrations of the test database.
+package migrations
+
+import "embed"
+
+// FS contains the SQL migrations.
+//
+//go:embed *.sql
+var FS embed.FS